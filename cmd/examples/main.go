@@ -0,0 +1,74 @@
+// Extract examples and expected answers from saved puzzle pages
+//
+//	go run ./cmd/examples -o day01 day01/puzzle.html
+//
+// writes day01/testdata/exampleN.txt and day01/examples_test.go (a table test
+// calling part1/part2(parseInput(r)) for each example with an answer - parts
+// which take extra arguments are skipped). An existing examples_test.go is
+// only replaced with -f.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/paulc/aoc2022/util/puzzle"
+)
+
+func main() {
+	dir := flag.String("o", ".", "Output directory")
+	force := flag.Bool("f", false, "Overwrite existing examples_test.go")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: examples [-f] [-o dir] <puzzle.html>")
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *dir, *force); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(file, dir string, force bool) error {
+	p, err := puzzle.ParseFile(file)
+	if err != nil {
+		return err
+	}
+	funcs, err := puzzle.ReadFuncs(dir)
+	if err != nil {
+		return err
+	}
+	if len(funcs) == 0 { // No solution yet - assume part(parseInput(r)) works
+		funcs = nil
+	}
+	// Generate before writing anything so a failure leaves dir unchanged
+	var b bytes.Buffer
+	if err := p.WriteTest(&b, funcs); err != nil {
+		return err
+	}
+	test := filepath.Join(dir, "examples_test.go")
+	if _, err := os.Stat(test); !force && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s exists (use -f to overwrite)", test)
+	}
+	if err := p.WriteTestdata(dir); err != nil {
+		return err
+	}
+	if err := os.WriteFile(test, b.Bytes(), 0o644); err != nil {
+		return err
+	}
+	for _, c := range p.Cases() {
+		if funcs != nil {
+			if err := funcs.Callable(c.Name); err != nil {
+				fmt.Printf("%s: skipped (%s)\n", c.Name, err)
+				continue
+			}
+		}
+		fmt.Printf("%s: %s -> %s\n", c.Name, c.Example, c.Expected)
+	}
+	return nil
+}
@@ -23,20 +23,43 @@ func (s *stack[T]) Push(v []T) {
 	*s = append(*s, v...)
 }
 
-func parseInput(r io.Reader) (stacks []stack[string], moves [][]int) {
-	head, tail := util.Split(util.Must(reader.Lines(r)), func(s string) bool { return s == "" })
-	moves = util.Map(tail, func(s string) []int { return util.Must(util.SlurpInt(s)) })
-	n := (len(head[0]) + 1) / 4
-	stacks = make([]stack[string], n)
-	for i := len(head) - 2; i >= 0; i-- {
-		for j := 0; j < n; j++ {
-			c := head[i][j*4+1]
-			if c != ' ' {
-				stacks[j] = append(stacks[j], string(c))
-			}
+type crane struct {
+	stacks []stack[string]
+	moves  [][]int
+}
+
+func parseStacks(lines []string, out *crane) error {
+	d, err := reader.ParseDiagram(lines)
+	if err != nil {
+		return err
+	}
+	out.stacks = util.Map(d.Columns, func(c []string) stack[string] { return c })
+	return nil
+}
+
+func parseMove(t *reader.Tokens) []int {
+	t.Keyword("move")
+	n := t.Int()
+	t.Keyword("from")
+	from := t.Int()
+	t.Keyword("to")
+	return []int{n, from, t.Int()}
+}
+
+func parseMoves(lines []string, out *crane) error {
+	for _, v := range lines {
+		m, err := reader.Parse(v, parseMove)
+		if err != nil {
+			return err
 		}
+		out.moves = append(out.moves, m)
 	}
-	return
+	return nil
+}
+
+func parseInput(r io.Reader) (stacks []stack[string], moves [][]int) {
+	c := util.Must(reader.ParseSections(r, reader.Blank, parseStacks, parseMoves))
+	return c.stacks, c.moves
 }
 
 func part1(stacks []stack[string], moves [][]int) (result string) {
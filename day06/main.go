@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/paulc/aoc2022/util"
-	"github.com/paulc/aoc2022/util/set"
 )
 
 func parseInput(r io.Reader) []byte {
@@ -14,12 +13,10 @@ func parseInput(r io.Reader) []byte {
 }
 
 func findStart(input []byte, startLen int) (n int) {
-	for i := 0; i < len(input)-startLen; {
-		unique := set.NewSetFrom(input[i : i+startLen]).Len()
+	for i, unique := range util.WindowDistinct(input, startLen) {
 		if unique == startLen {
 			return i + startLen
 		}
-		i += startLen - unique
 	}
 	return
 }
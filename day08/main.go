@@ -11,8 +11,13 @@ import (
 )
 
 type Tree struct {
-	height  int
-	visible bool
+	height int
+}
+
+var directions = []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}}
+
+func lower(a, b Tree) bool {
+	return a.height < b.height
 }
 
 func parseInput(r io.Reader) array.Array[Tree] {
@@ -22,50 +27,24 @@ func parseInput(r io.Reader) array.Array[Tree] {
 }
 
 func part1(input array.Array[Tree]) (result int) {
-	h, w := len(input), len(input[0])
-	for y := 0; y < h; y++ {
-		for _, v := range []struct{ start, dx int }{{0, 1}, {w - 1, -1}} {
-			for x, max := v.start, -1; x >= 0 && x < w; x += v.dx {
-				if input[y][x].height > max {
-					if !input[y][x].visible {
-						input[y][x].visible = true
-						result++
-					}
-					max = input[y][x].height
-				}
-			}
-		}
-	}
-	for x := 0; x < w; x++ {
-		for _, v := range []struct{ start, dy int }{{0, 1}, {h - 1, -1}} {
-			for y, max := v.start, -1; y >= 0 && y < h; y += v.dy {
-				if input[y][x].height > max {
-					if !input[y][x].visible {
-						input[y][x].visible = true
-						result++
-					}
-					max = input[y][x].height
-				}
+	masks := util.Map(directions, func(d struct{ dx, dy int }) array.Array[bool] { return input.Visible(d.dx, d.dy, lower) })
+	input.Each(func(e array.ArrayElement[Tree]) {
+		for _, m := range masks {
+			if m[e.Y][e.X] {
+				result++
+				return
 			}
 		}
-	}
+	})
 	return result
 }
 
 func part2(input array.Array[Tree]) (result int) {
-	h := len(input)
-	w := len(input[0])
+	views := util.Map(directions, func(d struct{ dx, dy int }) array.Array[int] { return input.ViewDistance(d.dx, d.dy, lower) })
 	input.Each(func(e array.ArrayElement[Tree]) {
 		score := 1
-		for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
-			view := 0
-			for x, y := e.X+v.dx, e.Y+v.dy; x >= 0 && x < w && y >= 0 && y < h; x, y = x+v.dx, y+v.dy {
-				view++
-				if input[y][x].height >= e.Val.height {
-					break
-				}
-			}
-			score *= view
+		for _, v := range views {
+			score *= v[e.Y][e.X]
 		}
 		if score > result {
 			result = score
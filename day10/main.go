@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/paulc/aoc2022/util"
@@ -22,27 +21,44 @@ func (crt Crt) String() string {
 	return strings.Join(out, "\n")
 }
 
-func parseInput(r io.Reader) [][]string {
-	return util.Map(util.Must(reader.Lines(r)), func(s string) []string { return strings.Split(s, " ") })
+type instr struct {
+	op  string
+	arg int
 }
 
-func runCpu(input [][]string, callback func(cycle, X int)) {
+func parseInstr(t *reader.Tokens) (out instr) {
+	if out.op = t.Ident("noop", "addx"); out.op == "addx" {
+		out.arg = t.Int()
+	}
+	return
+}
+
+func parseInput(r io.Reader) (out []instr) {
+	util.Must(reader.LineReader(r, func(s string) error {
+		v, err := reader.Parse(s, parseInstr)
+		out = append(out, v)
+		return err
+	}))
+	return
+}
+
+func runCpu(input []instr, callback func(cycle, X int)) {
 	X, cycle := 1, 0
 	for _, v := range input {
-		if v[0] == "noop" {
+		if v.op == "noop" {
 			cycle += 1
 			callback(cycle, X)
-		} else if v[0] == "addx" {
+		} else if v.op == "addx" {
 			for i := 0; i < 2; i++ {
 				cycle += 1
 				callback(cycle, X)
 			}
-			X += util.Must(strconv.Atoi(v[1]))
+			X += v.arg
 		}
 	}
 }
 
-func part1(input [][]string) (result int) {
+func part1(input []instr) (result int) {
 	runCpu(input, func(cycle, X int) {
 		if (cycle-20)%40 == 0 {
 			result += cycle * X
@@ -51,7 +67,7 @@ func part1(input [][]string) (result int) {
 	return
 }
 
-func part2(input [][]string) (result string) {
+func part2(input []instr) (result string) {
 	crt := Crt{}
 	runCpu(input, func(cycle, X int) {
 		if pos := cycle - 1; (pos%40) >= X-1 && (pos%40) <= X+1 {
@@ -42,34 +42,39 @@ func makeOp(op []string) func(int) int {
 	}
 }
 
-func parseInput(r io.Reader) (out []Monkey, lcm int) {
+type troop struct {
+	monkeys []Monkey
+	lcm     int
+}
+
+var sep = regexp.MustCompile(": ?")
+
+func parseMonkey(lines []string, out *troop) error {
 	m := Monkey{next: make(map[bool]int)}
-	re := regexp.MustCompile(": ?")
-	lcm = 1
-	for _, v := range util.Must(reader.Lines(r)) {
-		if v == "" {
-			out = append(out, m)
-			m = Monkey{next: make(map[bool]int)}
-		} else {
-			p := re.Split(strings.TrimSpace(v), 2)
-			switch p[0] {
-			case "Starting items":
-				m.items = util.Map(strings.Split(p[1], ", "), func(s string) int { return util.Must(strconv.Atoi(s)) })
-			case "Operation":
-				m.op = makeOp(strings.Fields(p[1]))
-			case "Test":
-				div := util.Must(strconv.Atoi(strings.Fields(p[1])[2]))
-				lcm = lcm * div
-				m.test = func(i int) bool { return i%div == 0 }
-			case "If true":
-				m.next[true] = util.Must(strconv.Atoi(strings.Fields(p[1])[3]))
-			case "If false":
-				m.next[false] = util.Must(strconv.Atoi(strings.Fields(p[1])[3]))
-			}
+	for _, v := range lines {
+		p := sep.Split(strings.TrimSpace(v), 2)
+		switch p[0] {
+		case "Starting items":
+			m.items = util.Map(strings.Split(p[1], ", "), func(s string) int { return util.Must(strconv.Atoi(s)) })
+		case "Operation":
+			m.op = makeOp(strings.Fields(p[1]))
+		case "Test":
+			div := util.Must(strconv.Atoi(strings.Fields(p[1])[2]))
+			out.lcm = util.Max(out.lcm, 1) * div
+			m.test = func(i int) bool { return i%div == 0 }
+		case "If true":
+			m.next[true] = util.Must(strconv.Atoi(strings.Fields(p[1])[3]))
+		case "If false":
+			m.next[false] = util.Must(strconv.Atoi(strings.Fields(p[1])[3]))
 		}
 	}
-	out = append(out, m)
-	return
+	out.monkeys = append(out.monkeys, m)
+	return nil
+}
+
+func parseInput(r io.Reader) (out []Monkey, lcm int) {
+	t := util.Must(reader.ParseSections(r, reader.Blank, parseMonkey))
+	return t.monkeys, t.lcm
 }
 
 func shuffle(monkeys []Monkey, div int, lcm int) {
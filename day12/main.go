@@ -19,22 +19,23 @@ type Hill struct {
 	inverted   path.Graph[point.Point]
 }
 
+var heights = func() map[rune]byte {
+	out := map[rune]byte{'S': 'a', 'E': 'z'}
+	for c := 'a'; c <= 'z'; c++ {
+		out[c] = byte(c)
+	}
+	return out
+}()
+
 func parseInput(r io.Reader) (hill Hill) {
-	a := util.Must(array.ArrayReader[byte](r, array.MakeStringSplitter(""), func(s string) (byte, error) { return s[0], nil }))
+	a, markers, err := array.ParseArrayMarkers(r, heights, "SEa")
+	if err != nil {
+		panic(err)
+	}
+	hill.start, hill.end = markers['S'][0], markers['E'][0]
+	hill.lowest = append(markers['S'], markers['a']...)
 	hill.graph = make(path.Graph[point.Point])
 	hill.inverted = make(path.Graph[point.Point])
-	a.Each(func(p array.ArrayElement[byte]) {
-		if p.Val == 'S' {
-			hill.start = point.Point{p.X, p.Y}
-			a[p.Y][p.X] = 'a'
-			hill.lowest = append(hill.lowest, point.Point{p.X, p.Y})
-		} else if p.Val == 'E' {
-			hill.end = point.Point{p.X, p.Y}
-			a[p.Y][p.X] = 'z'
-		} else if p.Val == 'a' {
-			hill.lowest = append(hill.lowest, point.Point{p.X, p.Y})
-		}
-	})
 	a.Each(func(e array.ArrayElement[byte]) {
 		adj := []path.Edge[point.Point]{}
 		adj_inv := []path.Edge[point.Point]{}
@@ -3,12 +3,12 @@ package main
 import (
 	"fmt"
 	"io"
-	"math"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/paulc/aoc2022/util"
+	"github.com/paulc/aoc2022/util/automaton"
 	"github.com/paulc/aoc2022/util/grid"
 	"github.com/paulc/aoc2022/util/point"
 	"github.com/paulc/aoc2022/util/reader"
@@ -35,21 +35,21 @@ func (b block) String() string {
 
 func parseInput(r io.Reader) (cave *grid.Grid[block]) {
 	paths := [][]point.Point{}
-	minX, maxX, maxY := math.MaxInt, 0, 0
+	bounds := point.FromPoints()
 	for _, v := range util.Must(reader.Lines(r)) {
 		paths = append(paths, util.Map(strings.Split(v, " -> "), func(s string) point.Point {
 			xy := util.Map(strings.Split(s, ","), func(s string) int { return util.Must(strconv.Atoi(s)) })
-			maxX = util.Max(xy[0], maxX)
-			minX = util.Min(xy[0], minX)
-			maxY = util.Max(xy[1], maxY)
-			return point.Point{xy[0], xy[1]}
+			p := point.Point{xy[0], xy[1]}
+			bounds = bounds.Include(p)
+			return p
 		}))
 	}
-	cave = util.Must(grid.NewGrid[block](minX-maxY, 0, maxX+maxY, maxY+2))
+	// Floor is two below the lowest rock and sand can spread diagonally
+	// from x=500 so allow for a triangle of height maxY+2
+	maxY := bounds.Max.Y
+	cave = util.Must(grid.NewGridFromRect[block](point.NewRect(bounds.Min.X-maxY, 0, bounds.Max.X+maxY, maxY+2)))
 	for _, p := range paths {
-		for i := 0; i < len(p)-1; i++ {
-			cave.DrawLine(p[i], p[i+1], rock)
-		}
+		cave.DrawPolyline(p, rock)
 	}
 	return
 }
@@ -71,17 +71,24 @@ func dropSand(cave *grid.Grid[block], p, t point.Point) bool {
 	return false
 }
 
+// Drop one grain per step until a grain doesn't come to rest (or blocks t).
+// Returns the number of grains dropped.
+func fill(cave *grid.Grid[block], t point.Point) int {
+	_, n, _ := automaton.Run(cave, func(cave *grid.Grid[block]) (*grid.Grid[block], bool) {
+		return cave, dropSand(cave, point.Point{500, 0}, t)
+	}, automaton.Options[*grid.Grid[block]]{})
+	return n
+}
+
 func part1(cave *grid.Grid[block]) (result int) {
-	for ; dropSand(cave, point.Point{500, 0}, point.Point{-1, -1}); result++ {
-	}
-	return
+	// Last grain falls into the abyss
+	return fill(cave, point.Point{-1, -1}) - 1
 }
 
 func part2(cave *grid.Grid[block]) (result int) {
-	cave.DrawLine(point.Point{cave.X0, cave.Y1}, point.Point{cave.X1, cave.Y1}, rock)
-	for ; dropSand(cave, point.Point{500, 0}, point.Point{500, 0}); result++ {
-	}
-	return result + 1
+	r := cave.Rect()
+	cave.DrawLine(point.Point{r.Min.X, r.Max.Y}, r.Max, rock)
+	return fill(cave, point.Point{500, 0})
 }
 
 func main() {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"runtime"
 
 	"github.com/paulc/aoc2022/util"
 	"github.com/paulc/aoc2022/util/point"
@@ -13,10 +12,13 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+type reading struct {
+	SX, SY, BX, BY int
+}
+
 func parseInput(r io.Reader) [][2]point.Point {
-	return util.Map(util.Must(reader.Lines(r)), func(s string) (out [2]point.Point) {
-		util.Must(fmt.Sscanf(s, "Sensor at x=%d, y=%d: closest beacon is at x=%d, y=%d", &out[0].X, &out[0].Y, &out[1].X, &out[1].Y))
-		return
+	return util.Map(util.Must(reader.ParseLines[reading](r, "Sensor at x={SX}, y={SY}: closest beacon is at x={BX}, y={BY}")), func(v reading) [2]point.Point {
+		return [2]point.Point{{v.SX, v.SY}, {v.BX, v.BY}}
 	})
 }
 
@@ -42,17 +44,18 @@ func merge(in [][2]int) (out [][2]int) {
 	return
 }
 
+func diamonds(input [][2]point.Point) []point.Diamond {
+	return util.Map(input, func(v [2]point.Point) point.Diamond { return point.NewDiamond(v[0], v[0].Distance(v[1])) })
+}
+
 func calculateExcluded(input [][2]point.Point, targetY int) (excluded [][2]int, beacons set.Set[int]) {
 	beacons = set.NewSet[int]()
 	for _, v := range input {
 		if v[1].Y == targetY {
 			beacons.Add(v[1].X)
 		}
-		target := point.Point{v[0].X, targetY}
-		d := v[0].Distance(v[1])
-		dx := d - v[0].Ydistance(target)
-		if dx >= 0 {
-			excluded = append(excluded, [2]int{v[0].X - dx, v[0].X + dx})
+		if x0, x1, ok := point.NewDiamond(v[0], v[0].Distance(v[1])).Row(targetY); ok {
+			excluded = append(excluded, [2]int{x0, x1})
 		}
 	}
 	excluded = merge(excluded)
@@ -72,25 +75,12 @@ func part1(input [][2]point.Point, targetY int) (result int) {
 	return
 }
 
-func part2(input [][2]point.Point, maxXY int) (result int) {
-	ncpu := runtime.NumCPU()
-	out := make(chan int)
-	for start := 0; start < maxXY; start += maxXY / ncpu {
-		go func(start, count int, out chan int) {
-			for i := start; i < start+count; i++ {
-				excluded, _ := calculateExcluded(input, i)
-				if len(excluded) > 1 {
-					out <- i + (excluded[0][1]+1)*4000000
-					return
-				}
-			}
-		}(start, maxXY/ncpu, out)
-	}
-	select {
-	case result = <-out:
-		close(out)
+func part2(input [][2]point.Point, maxXY int) int {
+	uncovered := point.UncoveredCandidates(diamonds(input), point.NewRect(0, 0, maxXY, maxXY))
+	if len(uncovered) != 1 {
+		panic(fmt.Sprintf("Expected single uncovered point: %v", uncovered))
 	}
-	return
+	return uncovered[0].X*4000000 + uncovered[0].Y
 }
 
 func main() {
@@ -21,9 +21,9 @@ type cave struct {
 }
 
 type valve struct {
-	key   string
-	flow  int
-	paths []string
+	Key   string
+	Flow  int
+	Paths []string
 }
 
 type state struct {
@@ -35,23 +35,21 @@ type state struct {
 
 func parseInput(r io.Reader) cave {
 	paths := make(path.Graph[string])
-	valves := util.Map(util.Must(reader.Lines(r)), func(s string) (out valve) {
-		util.Must(fmt.Sscanf(s, "Valve %s has flow rate=%d;", &out.key, &out.flow))
-		out.paths = strings.Split(strings.SplitN(s, " ", 10)[9], ", ")
-		paths[out.key] = util.Map(out.paths, func(e string) path.Edge[string] {
+	valves := util.Must(reader.ParseLines[valve](r, `Valve (?P<Key>\w+) has flow rate=(?P<Flow>\d+); tunnels? leads? to valves? (?P<Paths>.*)`))
+	for _, v := range valves {
+		paths[v.Key] = util.Map(v.Paths, func(e string) path.Edge[string] {
 			return path.Edge[string]{e, 1.0}
 		})
-		return
-	})
-	interesting := util.Filter(valves, func(v valve) bool { return v.flow > 0 })
+	}
+	interesting := util.Filter(valves, func(v valve) bool { return v.Flow > 0 })
 	routes := []string{"AA"}
 	costs := make(map[string]int)
 	valveMap := make(map[string]int)
 	available := set.NewSet[string]()
 	util.Apply(interesting, func(v valve) {
-		routes = append(routes, v.key)
-		valveMap[v.key] = v.flow
-		available.Add(v.key)
+		routes = append(routes, v.Key)
+		valveMap[v.Key] = v.Flow
+		available.Add(v.Key)
 	})
 	for _, start := range routes {
 		for _, r := range paths.AstarMultiple(start, routes, func(s string) float64 { return 1.0 }) {
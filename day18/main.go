@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -27,19 +28,27 @@ func (p xyz) inside(p1, p2 xyz) bool {
 }
 
 func fill(reachable, cubes set.Set[xyz], p, pmin, pmax xyz) {
-	for _, p2 := range p.adjacent() {
-		if p2.inside(pmin, pmax) && !reachable.Has(p2) && !cubes.Has(p2) {
-			reachable.Add(p2)
-			fill(reachable, cubes, p2, pmin, pmax)
+	stack := []xyz{p}
+	for len(stack) > 0 {
+		p = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p2 := range p.adjacent() {
+			if p2.inside(pmin, pmax) && !reachable.Has(p2) && !cubes.Has(p2) {
+				reachable.Add(p2)
+				stack = append(stack, p2)
+			}
 		}
 	}
 }
 
 func parseInput(r io.Reader) (out set.Set[xyz]) {
-	out = set.NewSetFrom(util.Map(util.Must(reader.Lines(r)), func(s string) (p xyz) {
-		util.Must(fmt.Sscanf(s, "%d,%d,%d", &p.x, &p.y, &p.z))
+	out = set.NewSet[xyz]()
+	for v := range reader.Scan(context.Background(), r, func(s string) (p xyz, err error) {
+		_, err = fmt.Sscanf(s, "%d,%d,%d", &p.x, &p.y, &p.z)
 		return
-	}))
+	}) {
+		out.Add(util.Must(v.Val, v.Err))
+	}
 	return
 }
 
@@ -62,16 +62,27 @@ type puzzle struct {
 	start_state state
 }
 
+type record struct {
+	ID                        int
+	Ore, Clay                 int
+	ObsidianOre, ObsidianClay int
+	GeodeOre, GeodeObsidian   int
+}
+
+const template = "Blueprint {ID}: Each ore robot costs {Ore} ore. Each clay robot costs {Clay} ore. Each obsidian robot costs {ObsidianOre} ore and {ObsidianClay} clay. Each geode robot costs {GeodeOre} ore and {GeodeObsidian} obsidian."
+
 func parseInput(r io.Reader) (out puzzle) {
-	util.Must(reader.LineReader(r, func(s string) error {
-		b := blueprint{}
-		util.Must(fmt.Sscanf(s, "Blueprint %d: Each ore robot costs %d ore. Each clay robot costs %d ore. Each obsidian robot costs %d ore and %d clay. Each geode robot costs %d ore and %d obsidian.", &b.id, &b.costs[ore][ore], &b.costs[clay][ore], &b.costs[obsidian][ore], &b.costs[obsidian][clay], &b.costs[geode][ore], &b.costs[geode][obsidian]))
+	for _, v := range util.Must(reader.ParseLines[record](r, template)) {
+		b := blueprint{id: v.ID}
+		b.costs[ore][ore] = v.Ore
+		b.costs[clay][ore] = v.Clay
+		b.costs[obsidian][ore], b.costs[obsidian][clay] = v.ObsidianOre, v.ObsidianClay
+		b.costs[geode][ore], b.costs[geode][obsidian] = v.GeodeOre, v.GeodeObsidian
 		for i := 0; i < 4; i++ {
 			b.needed[i] = util.Max(b.costs[0][i], b.costs[1][i], b.costs[2][i], b.costs[3][i])
 		}
 		out.blueprints = append(out.blueprints, b)
-		return nil
-	}))
+	}
 	out.start_state.robots[ore] = 1
 	return
 }
@@ -10,5 +10,5 @@ import (
 func main() {
 	input := parseInput(util.Must(os.Open("input")))
 	fmt.Println("Part1:", part1(input))
-	fmt.Println("Part2:", part2(input, part2_map))
+	fmt.Println("Part2:", part2(input))
 }
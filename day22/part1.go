@@ -1,15 +1,13 @@
 package main
 
 import (
-	"bytes"
-	"errors"
 	"fmt"
 	"io"
-	"regexp"
-	"strconv"
+	"strings"
 
 	"github.com/paulc/aoc2022/util"
 	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/point"
 	"github.com/paulc/aoc2022/util/reader"
 )
 
@@ -25,36 +23,9 @@ const (
 	up
 )
 
-type facing int8
+var facing = map[point.Dir]int{point.E: 0, point.S: 1, point.W: 2, point.N: 3}
 
-const (
-	R facing = iota
-	D
-	L
-	U
-	nFacing
-)
-
-func (f facing) String() string {
-	return map[facing]string{R: "R", D: "D", L: "L", U: "U"}[f]
-}
-
-var dxy = map[facing]struct{ dx, dy int }{
-	R: {1, 0}, L: {-1, 0}, U: {0, -1}, D: {0, 1},
-}
-
-func strToTile(s string) (tile, error) {
-	switch s {
-	case ".":
-		return open, nil
-	case "#":
-		return solid, nil
-	case " ":
-		return void, nil
-	default:
-		return void, errors.New("Invalid tile")
-	}
-}
+var tiles = map[rune]tile{'.': open, '#': solid, ' ': void}
 
 func (t tile) String() string {
 	return map[tile]string{open: ".", solid: "#", void: " ", left: "<", right: ">", up: "^", down: "v"}[t]
@@ -76,7 +47,7 @@ func (m move) String() string {
 
 type pos struct {
 	x, y int
-	dir  facing
+	dir  point.Dir
 }
 
 type puzzle struct {
@@ -89,17 +60,17 @@ type puzzle struct {
 func (s *puzzle) Move(m move) {
 	if m.turn {
 		if m.direction == "R" {
-			s.current.dir = (s.current.dir + 1) % nFacing
+			s.current.dir = s.current.dir.TurnRight()
 		} else {
-			s.current.dir = (s.current.dir + 3) % nFacing
+			s.current.dir = s.current.dir.TurnLeft()
 		}
 	} else {
-		delta := dxy[s.current.dir]
+		dx, dy := s.current.dir.Delta()
 		for i := 0; i < m.count; i++ {
 			onmap := false
 			x, y := s.current.x, s.current.y
 			for !onmap {
-				x1, y1 := x+delta.dx, y+delta.dy
+				x1, y1 := x+dx, y+dy
 				if x1 < 0 {
 					x1 = s.w - 1
 				}
@@ -128,39 +99,27 @@ func (s *puzzle) Move(m move) {
 }
 
 func parseInput(r io.Reader) (out puzzle) {
-	head, tail, _ := reader.HeadFunc(r, func(b []byte) bool { return bytes.Equal(b, []byte{'\n'}) }, true)
-	out.cave = util.Must(array.ArrayReader[tile](&head, array.MakeStringSplitter(""), strToTile))
-	re := regexp.MustCompile(`(\d+|[LR])`)
-	b, i := tail.Bytes(), 0
-	for m := re.FindIndex(b[i:]); m != nil; {
-		a := string(b[i:][m[0]:m[1]])
-		mv := move{}
-		if a == "L" || a == "R" {
-			mv.direction = a
-			mv.turn = true
-		} else {
-			mv.count = util.Must(strconv.Atoi(a))
-		}
-		out.moves = append(out.moves, mv)
-		i += m[1]
-		m = re.FindIndex(b[i:])
-	}
+	out = util.Must(reader.ParseSections(r, reader.Blank, parseMap, parsePath))
+	out.start.dir = point.E
 	for out.start.x = 0; out.cave[0][out.start.x] != open; out.start.x++ {
 	}
 	out.current = out.start
-	out.h = len(out.cave)
-	for i := 0; i < out.h; i++ {
-		if l := len(out.cave[i]); l > out.w {
-			out.w = l
-		}
-	}
-	for i := 0; i < out.h; i++ {
-		if l := len(out.cave[i]); l < out.w {
-			for j := 0; j < out.w-l; j++ {
-				out.cave[i] = append(out.cave[i], void)
-			}
-		}
-	}
+	out.h, out.w = len(out.cave), len(out.cave[0])
+	return
+}
+
+func parseMap(lines []string, out *puzzle) (err error) {
+	out.cave, err = array.ParseArray(strings.NewReader(strings.Join(lines, "\n")), tiles)
+	return
+}
+
+var path = reader.Many(reader.Alt(
+	reader.Map(reader.IntRule, func(n int) move { return move{count: n} }),
+	reader.Map(reader.IdentRule("L", "R"), func(d string) move { return move{turn: true, direction: d} }),
+))
+
+func parsePath(lines []string, out *puzzle) (err error) {
+	out.moves, err = reader.Parse(strings.Join(lines, ""), path)
 	return
 }
 
@@ -168,5 +127,5 @@ func part1(input puzzle) (result int) {
 	for _, v := range input.moves {
 		input.Move(v)
 	}
-	return (input.current.y+1)*1000 + (input.current.x+1)*4 + int(input.current.dir)
+	return (input.current.y+1)*1000 + (input.current.x+1)*4 + facing[input.current.dir]
 }
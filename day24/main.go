@@ -4,165 +4,126 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
 
 	"github.com/paulc/aoc2022/util"
+	"github.com/paulc/aoc2022/util/automaton"
 	"github.com/paulc/aoc2022/util/grid"
 	"github.com/paulc/aoc2022/util/point"
-	"github.com/paulc/aoc2022/util/reader"
 	"github.com/paulc/aoc2022/util/set"
 )
 
-type blizzard struct {
-	dx, dy int
+// Blizzards in a cell of the valley (bit 1<<d for each direction)
+type blizzards uint8
+
+func (b blizzards) has(d point.Dir) bool {
+	return b&(1<<d) != 0
 }
 
 type puzzle struct {
-	blizzards  map[point.Point][]blizzard
-	w, h       int
+	valley     *grid.Grid[blizzards] // Valley interior (wraps as a torus)
 	start, end point.Point
 }
 
-func drawBlizzard(blizzards map[point.Point][]blizzard, w, h int) string {
-	g, _ := grid.NewGrid[string](0, 0, w-1, h-1)
-	for x := 0; x < w; x++ {
-		for y := 0; y < h; y++ {
-			g.Set(point.Point{x, y}, ".")
+func drawBlizzard(valley *grid.Grid[blizzards], start, end point.Point) string {
+	g, _ := grid.NewGrid[string](valley.X0-1, valley.Y0-1, valley.X1+1, valley.Y1+1)
+	for y := g.Y0; y <= g.Y1; y++ {
+		for x := g.X0; x <= g.X1; x++ {
+			g.Set(point.Point{x, y}, "#")
 		}
 	}
-	for x := 2; x < w; x++ {
-		g.Set(point.Point{x, 0}, "#")
-		g.Set(point.Point{w - x - 1, h - 1}, "#")
-	}
-	for y := 0; y < h; y++ {
-		g.Set(point.Point{0, y}, "#")
-		g.Set(point.Point{w - 1, y}, "#")
-	}
-	for k, v := range blizzards {
-		if len(v) == 1 {
-			switch v[0] {
-			case blizzard{-1, 0}:
-				g.Set(k, "<")
-			case blizzard{1, 0}:
-				g.Set(k, ">")
-			case blizzard{0, -1}:
-				g.Set(k, "^")
-			case blizzard{0, 1}:
-				g.Set(k, "v")
+	g.Set(start, ".")
+	g.Set(end, ".")
+	for y := valley.Y0; y <= valley.Y1; y++ {
+		for x := valley.X0; x <= valley.X1; x++ {
+			p := point.Point{x, y}
+			n, s := 0, "."
+			for _, d := range blizzardDirs {
+				if valley.Get(p).has(d) {
+					n, s = n+1, d.Arrow()
+				}
 			}
-		} else {
-			g.Set(k, fmt.Sprintf("%d", len(v)))
+			if n > 1 {
+				s = fmt.Sprintf("%d", n)
+			}
+			g.Set(p, s)
 		}
 	}
 	return g.String()
 }
 
 func parseInput(r io.Reader) (out puzzle) {
-	out.blizzards = make(map[point.Point][]blizzard)
-	y := 0
-	for _, l := range util.Must(reader.Lines(r)) {
-		out.w = len(l)
-		for x, v := range strings.Split(l, "") {
-			switch v {
-			case "<":
-				out.blizzards[point.Point{x, y}] = append(out.blizzards[point.Point{x, y}], blizzard{-1, 0})
-			case ">":
-				out.blizzards[point.Point{x, y}] = append(out.blizzards[point.Point{x, y}], blizzard{1, 0})
-			case "^":
-				out.blizzards[point.Point{x, y}] = append(out.blizzards[point.Point{x, y}], blizzard{0, -1})
-			case "v":
-				out.blizzards[point.Point{x, y}] = append(out.blizzards[point.Point{x, y}], blizzard{0, 1})
-			}
+	symbols := map[rune]bool{'#': true, '.': false, '<': false, '>': false, '^': false, 'v': false}
+	g, markers, err := grid.ParseGridMarkers(r, symbols, "<>^v")
+	if err != nil {
+		panic(err)
+	}
+	out.valley = util.Must(grid.NewGrid[blizzards](1, 1, g.Width-2, g.Height-2)).SetTopology(grid.Torus)
+	for c, pp := range markers {
+		d := util.Must(point.ParseDir(string(c)))
+		for _, p := range pp {
+			out.valley.Set(p, out.valley.Get(p)|1<<d)
 		}
-		y++
 	}
-	out.h = y
 	out.start = point.Point{1, 0}
-	out.end = point.Point{out.w - 2, out.h - 1}
+	out.end = point.Point{g.Width - 2, g.Height - 1}
 	return
 }
 
-func moveBlizzards(in map[point.Point][]blizzard, w, h int) (out map[point.Point][]blizzard) {
-	out = make(map[point.Point][]blizzard)
-	for p, v := range in {
-		for _, b := range v {
-			next := point.Point{p.X + b.dx, p.Y + b.dy}
-			switch {
-			case next.X == 0:
-				next.X = w - 2
-			case next.X == w-1:
-				next.X = 1
-			case next.Y == 0:
-				next.Y = h - 2
-			case next.Y == h-1:
-				next.Y = 1
-			}
-			out[next] = append(out[next], b)
-		}
+var blizzardDirs = []point.Dir{point.N, point.E, point.S, point.W}
+
+// Neighbours in blizzardDirs order
+func neighbours(p point.Point) (out []point.Point) {
+	for _, d := range blizzardDirs {
+		out = append(out, p.Step(d, 1))
 	}
 	return
 }
 
-func options(p point.Point, blizzards map[point.Point][]blizzard, w, h int, start, end point.Point) (out []point.Point) {
-	for _, v := range []struct{ dx, dy int }{{-1, 0}, {1, 0}, {0, -1}, {0, 1}, {0, 0}} {
-		next := point.Point{p.X + v.dx, p.Y + v.dy}
-		if (next.X > 0 && next.X <= w-2 && next.Y > 0 && next.Y <= h-2) || next == start || next == end {
-			if _, found := blizzards[next]; !found {
-				out = append(out, next)
+// Blizzards arrive from the neighbour behind them
+func moveBlizzards(valley *grid.Grid[blizzards]) *grid.Grid[blizzards] {
+	out, _, err := automaton.StepGrid(valley, neighbours, func(_ point.Point, _ blizzards, n []blizzards) (v blizzards) {
+		for i, d := range blizzardDirs {
+			if n[i].has(d.Reverse()) {
+				v |= 1 << d.Reverse()
 			}
 		}
+		return
+	})
+	if err != nil {
+		panic(err)
 	}
-	return
+	return out
+}
+
+// The expedition can move to (or stay in) any cell clear of blizzards
+func moveExpedition(current set.Set[point.Point], input puzzle) set.Set[point.Point] {
+	next, _ := automaton.StepSet(current, automaton.VonNeumann, func(p point.Point, alive bool, n int) bool {
+		open := p == input.start || p == input.end || (input.valley.CheckBounds(p) && input.valley.Get(p) == 0)
+		return (alive || n > 0) && open
+	})
+	return next
 }
 
 func part1(input puzzle) (result int) {
-	current := set.NewSetFrom[point.Point]([]point.Point{{1, 0}})
-	for {
+	current := set.NewSetFrom([]point.Point{input.start})
+	for !current.Has(input.end) {
 		result++
-		input.blizzards = moveBlizzards(input.blizzards, input.w, input.h)
-		next := set.NewSet[point.Point]()
-		for p := range current {
-			opts := options(p, input.blizzards, input.w, input.h, input.start, input.end)
-			for _, v := range opts {
-				next.Add(v)
-			}
-		}
-		if next.Has(input.end) {
-			break
-		}
-		current = next
+		input.valley = moveBlizzards(input.valley)
+		current = moveExpedition(current, input)
 	}
 	return result
 }
 
 func part2(input puzzle) (result int) {
-	current := set.NewSetFrom[point.Point]([]point.Point{{1, 0}})
-	target := input.end
-	trips := 0
-	for {
+	current := set.NewSetFrom([]point.Point{input.start})
+	targets := []point.Point{input.end, input.start, input.end}
+	for len(targets) > 0 {
 		result++
-		input.blizzards = moveBlizzards(input.blizzards, input.w, input.h)
-		next := set.NewSet[point.Point]()
-		for p := range current {
-			opts := options(p, input.blizzards, input.w, input.h, input.start, input.end)
-			for _, v := range opts {
-				next.Add(v)
-			}
-		}
-		if next.Has(target) {
-			trips++
-			if trips == 3 {
-				break
-			}
-			if target == input.end {
-				target = input.start
-				current = set.NewSetFrom[point.Point]([]point.Point{input.end})
-			} else {
-				target = input.end
-				current = set.NewSetFrom[point.Point]([]point.Point{input.start})
-			}
-		} else {
-			current = next
+		input.valley = moveBlizzards(input.valley)
+		current = moveExpedition(current, input)
+		if current.Has(targets[0]) {
+			current = set.NewSetFrom([]point.Point{targets[0]})
+			targets = targets[1:]
 		}
 	}
 	return result
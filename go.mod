@@ -2,4 +2,7 @@ module github.com/paulc/aoc2022
 
 go 1.18
 
-require golang.org/x/exp v0.0.0-20221114172223-0cf76af32a3a // indirect
+require (
+	github.com/klauspost/compress v1.15.15
+	golang.org/x/exp v0.0.0-20221114172223-0cf76af32a3a
+)
@@ -22,18 +22,27 @@ func ArrayReaderFunc[T any](r io.Reader, f func(s string) ([]T, error)) (out Arr
 	return
 }
 
+// Split line and parse each field - parse errors are returned as a
+// *reader.ParseError with the column of the field
 func LineParser[T any](line string, splitF func(string) ([]string, error), parseF func(string) (T, error)) (out []T, err error) {
 	if len(line) > 0 {
 		var split []string
 		split, err = splitF(line)
 		if err != nil {
-			return
+			return nil, &reader.ParseError{Text: line, Err: err}
 		}
+		pos := 0
 		for _, v := range split {
+			// Fields are normally substrings of line (in order)
+			col := 0
+			if i := strings.Index(line[pos:], v); i >= 0 {
+				col = pos + i + 1
+				pos += i + len(v)
+			}
 			var p T
 			p, err = parseF(v)
 			if err != nil {
-				return
+				return nil, &reader.ParseError{Column: col, Text: line, Err: err}
 			}
 			out = append(out, p)
 		}
@@ -2,11 +2,13 @@ package array
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/paulc/aoc2022/util/reader"
 	"golang.org/x/exp/slices"
 )
 
@@ -54,6 +56,18 @@ func TestLineParserErr(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected parse error")
 	}
+	var pe *reader.ParseError
+	if !errors.As(err, &pe) || pe.Column != 3 || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error(err)
+	}
+}
+
+func TestArrayReaderErr(t *testing.T) {
+	_, err := ArrayReader(bytes.NewBufferString("1 2 3\n4 5 66x"), SplitWS, strconv.Atoi)
+	var pe *reader.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 5 || pe.Text != "4 5 66x" {
+		t.Error(err)
+	}
 }
 
 func TestArrayReader(t *testing.T) {
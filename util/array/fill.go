@@ -0,0 +1,113 @@
+package array
+
+import (
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+)
+
+type Region struct {
+	ID        int
+	X, Y      int
+	Size      int
+	Perimeter int
+}
+
+func (a Array[T]) AdjacentDiagonal(x, y int) (out []ArrayElement[T]) {
+	if len(a) == 0 {
+		return
+	}
+	h := len(a)
+	w := len(a[0])
+	for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}, {-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		x1 := x + v.dx
+		y1 := y + v.dy
+		if x1 >= 0 && x1 < w && y1 >= 0 && y1 < h {
+			out = append(out, ArrayElement[T]{x1, y1, a[y1][x1]})
+		}
+	}
+	return
+}
+
+// Iterative flood fill from x,y (4-connected) - returns the set of points
+// reached where f is true
+func (a Array[T]) FloodFill(x, y int, f func(ArrayElement[T]) bool) set.Set[point.Point] {
+	return a.floodFill(x, y, f, a.Adjacent)
+}
+
+// Flood fill including diagonals (8-connected)
+func (a Array[T]) FloodFillDiagonal(x, y int, f func(ArrayElement[T]) bool) set.Set[point.Point] {
+	return a.floodFill(x, y, f, a.AdjacentDiagonal)
+}
+
+func (a Array[T]) floodFill(x, y int, f func(ArrayElement[T]) bool, adjacent func(x, y int) []ArrayElement[T]) set.Set[point.Point] {
+	out := set.NewSet[point.Point]()
+	if y < 0 || y >= len(a) || x < 0 || x >= len(a[y]) || !f(ArrayElement[T]{x, y, a[y][x]}) {
+		return out
+	}
+	out.Add(point.Point{x, y})
+	stack := []point.Point{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range adjacent(p.X, p.Y) {
+			p1 := point.Point{e.X, e.Y}
+			if !out.Has(p1) && f(e) {
+				out.Add(p1)
+				stack = append(stack, p1)
+			}
+		}
+	}
+	return out
+}
+
+// Label connected regions (4-connected) of cells where same(seed, cell) is
+// true - each cell is compared with the first (seed) cell of the region rather
+// than its neighbour so same should normally be an equivalence (eg. ==).
+// Returns an array of region IDs (indexes into regions)
+func (a Array[T]) LabelRegions(same func(a, b T) bool) (Array[int], []Region) {
+	return a.labelRegions(same, a.Adjacent)
+}
+
+// Label connected regions including diagonals (8-connected)
+func (a Array[T]) LabelRegionsDiagonal(same func(a, b T) bool) (Array[int], []Region) {
+	return a.labelRegions(same, a.AdjacentDiagonal)
+}
+
+func (a Array[T]) labelRegions(same func(a, b T) bool, adjacent func(x, y int) []ArrayElement[T]) (Array[int], []Region) {
+	if len(a) == 0 {
+		return Array[int]{}, []Region{}
+	}
+	h := len(a)
+	w := len(a[0])
+	labels := make(Array[int], h)
+	for y := 0; y < h; y++ {
+		labels[y] = make([]int, w)
+		for x := 0; x < w; x++ {
+			labels[y][x] = -1
+		}
+	}
+	regions := []Region{}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if labels[y][x] != -1 {
+				continue
+			}
+			v := a[y][x]
+			r := Region{ID: len(regions), X: x, Y: y}
+			for p := range a.floodFill(x, y, func(e ArrayElement[T]) bool { return labels[e.Y][e.X] == -1 && same(v, e.Val) }, adjacent) {
+				labels[p.Y][p.X] = r.ID
+				r.Size++
+			}
+			regions = append(regions, r)
+		}
+	}
+	// Perimeter is always counted on cell edges
+	labels.Each(func(e ArrayElement[int]) {
+		for _, p := range (point.Point{e.X, e.Y}).Adjacent() {
+			if p.X < 0 || p.X >= w || p.Y < 0 || p.Y >= h || labels[p.Y][p.X] != e.Val {
+				regions[e.Val].Perimeter++
+			}
+		}
+	})
+	return labels, regions
+}
@@ -0,0 +1,42 @@
+package array
+
+import (
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+)
+
+func TestArrayFloodFill(t *testing.T) {
+	a := Array[int]{{0, 1, 0, 0}, {1, 1, 0, 1}, {0, 0, 1, 1}}
+	zero := func(e ArrayElement[int]) bool { return e.Val == 0 }
+	one := func(e ArrayElement[int]) bool { return e.Val == 1 }
+	if f := a.FloodFill(2, 0, zero); !f.Equals(set.NewSetFrom([]point.Point{{2, 0}, {3, 0}, {2, 1}})) {
+		t.Error(f)
+	}
+	if f := a.FloodFillDiagonal(1, 0, one); f.Len() != 6 {
+		t.Error(f)
+	}
+	if f := a.FloodFill(5, 5, zero); f.Len() != 0 {
+		t.Error(f)
+	}
+}
+
+func TestArrayLabelRegions(t *testing.T) {
+	a := Array[int]{{0, 1, 0, 0}, {1, 1, 0, 1}, {0, 0, 1, 1}}
+	eq := func(a, b int) bool { return a == b }
+	labels, regions := a.LabelRegions(eq)
+	expected := Array[int]{{0, 1, 2, 2}, {1, 1, 2, 3}, {4, 4, 3, 3}}
+	if !labels.EqualFunc(expected, eq) {
+		t.Error(labels)
+	}
+	for i, v := range []struct{ size, perimeter int }{{1, 4}, {3, 8}, {3, 8}, {3, 8}, {2, 6}} {
+		if regions[i].Size != v.size || regions[i].Perimeter != v.perimeter {
+			t.Error(i, regions[i])
+		}
+	}
+	_, regions = a.LabelRegionsDiagonal(eq)
+	if len(regions) != 3 {
+		t.Error(regions)
+	}
+}
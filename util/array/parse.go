@@ -0,0 +1,115 @@
+package array
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/paulc/aoc2022/util"
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/reader"
+	"golang.org/x/exp/slices"
+)
+
+// Parse ASCII map into array using symbols to map each rune
+func ParseArray[T any](r io.Reader, symbols map[rune]T) (Array[T], error) {
+	out, _, err := ParseArrayMarkers(r, symbols, "")
+	return out, err
+}
+
+// Parse ASCII map into array using symbols to map each rune and return the
+// positions of any runes in markers. Short lines are padded to the longest
+// line (using ' ' from symbols if present, otherwise the zero value) and
+// trailing blank lines are ignored. Invalid symbols are returned as a
+// *reader.ParseError.
+func ParseArrayMarkers[T any](r io.Reader, symbols map[rune]T, markers string) (out Array[T], found map[rune][]point.Point, err error) {
+	found = make(map[rune][]point.Point)
+	w, y := 0, 0
+	_, err = reader.LineReader(r, func(s string) error {
+		row := []T{}
+		for x, c := range []rune(s) {
+			v, ok := symbols[c]
+			if !ok {
+				return &reader.ParseError{Column: x + 1, Err: fmt.Errorf("Invalid symbol %q", c)}
+			}
+			if strings.ContainsRune(markers, c) {
+				found[c] = append(found[c], point.Point{x, y})
+			}
+			row = append(row, v)
+		}
+		w = util.Max(w, len(row))
+		out = append(out, row)
+		y++
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for len(out) > 0 && len(out[len(out)-1]) == 0 {
+		out = out[:len(out)-1]
+	}
+	pad := symbols[' ']
+	for y := range out {
+		for len(out[y]) < w {
+			out[y] = append(out[y], pad)
+		}
+	}
+	return out, found, nil
+}
+
+// Format array as ASCII map (reverse of ParseArrayMarkers). Marker runes are
+// drawn at their positions and if several runes map to the same value the
+// lowest non-marker rune is used. Unknown values are shown as '?'.
+func Format[T comparable](a Array[T], symbols map[rune]T, markers map[rune][]point.Point) string {
+	reverse := reverseSymbols(symbols, markers)
+	rows := make([][]rune, len(a))
+	for y, row := range a {
+		rows[y] = make([]rune, len(row))
+		for x, v := range row {
+			if c, ok := reverse[v]; ok {
+				rows[y][x] = c
+			} else {
+				rows[y][x] = '?'
+			}
+		}
+	}
+	for c, pp := range markers {
+		for _, p := range pp {
+			if p.Y >= 0 && p.Y < len(rows) && p.X >= 0 && p.X < len(rows[p.Y]) {
+				rows[p.Y][p.X] = c
+			}
+		}
+	}
+	return strings.Join(mapRows(rows), "\n")
+}
+
+func reverseSymbols[T comparable](symbols map[rune]T, markers map[rune][]point.Point) map[T]rune {
+	runes := []rune{}
+	for c := range symbols {
+		runes = append(runes, c)
+	}
+	// Non-marker runes sort first
+	slices.SortFunc(runes, func(a, b rune) bool {
+		_, ma := markers[a]
+		_, mb := markers[b]
+		if ma != mb {
+			return mb
+		}
+		return a < b
+	})
+	out := make(map[T]rune)
+	for _, c := range runes {
+		if _, ok := out[symbols[c]]; !ok {
+			out[symbols[c]] = c
+		}
+	}
+	return out
+}
+
+func mapRows(rows [][]rune) []string {
+	out := make([]string, len(rows))
+	for i, r := range rows {
+		out[i] = string(r)
+	}
+	return out
+}
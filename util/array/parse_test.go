@@ -0,0 +1,53 @@
+package array
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/reader"
+	"golang.org/x/exp/slices"
+)
+
+const data_map = `Sab
+ #.E
+..
+
+`
+
+var map_symbols = map[rune]byte{'S': 'a', 'E': 'z', 'a': 'a', 'b': 'b', '#': '#', '.': '.', ' ': ' '}
+
+func TestParseArray(t *testing.T) {
+	a, markers, err := ParseArrayMarkers(bytes.NewBufferString(data_map), map_symbols, "SE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Array[byte]{[]byte("aab "), []byte(" #.z"), []byte("..  ")}
+	if !a.EqualFunc(expected, func(a, b byte) bool { return a == b }) {
+		t.Errorf("%q", a)
+	}
+	if !slices.Equal(markers['S'], []point.Point{{0, 0}}) || !slices.Equal(markers['E'], []point.Point{{3, 1}}) {
+		t.Error(markers)
+	}
+	if s := Format(a, map_symbols, markers); s != "Sab \n #.E\n..  " {
+		t.Errorf("%q", s)
+	}
+}
+
+func TestParseArrayErr(t *testing.T) {
+	_, err := ParseArray(bytes.NewBufferString("ab\nax"), map_symbols)
+	var pe *reader.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 2 || pe.Text != "ax" {
+		t.Fatal(err)
+	}
+	if err.Error() != `line 2, column 2: Invalid symbol 'x' ["ax"]` {
+		t.Error(err)
+	}
+}
+
+func TestFormatUnknown(t *testing.T) {
+	if s := Format(Array[int]{{0, 1}, {2, 0}}, map[rune]int{'.': 0, '#': 1}, nil); s != ".#\n?." {
+		t.Errorf("%q", s)
+	}
+}
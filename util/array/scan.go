@@ -0,0 +1,93 @@
+package array
+
+// Cells from x,y (exclusive) to the edge in direction dx,dy (panics if
+// dx,dy is 0,0)
+func (a Array[T]) Ray(x, y, dx, dy int) (out []ArrayElement[T]) {
+	checkStep(dx, dy)
+	for x, y = x+dx, y+dy; a.inside(x, y); x, y = x+dx, y+dy {
+		out = append(out, ArrayElement[T]{x, y, a[y][x]})
+	}
+	return
+}
+
+func checkStep(dx, dy int) {
+	if dx == 0 && dy == 0 {
+		panic("Invalid direction: 0,0")
+	}
+}
+
+func (a Array[T]) inside(x, y int) bool {
+	return y >= 0 && y < len(a) && x >= 0 && x < len(a[y])
+}
+
+func (a Array[T]) Row(y int) []ArrayElement[T] {
+	return append([]ArrayElement[T]{{0, y, a[y][0]}}, a.Ray(0, y, 1, 0)...)
+}
+
+func (a Array[T]) Column(x int) []ArrayElement[T] {
+	return append([]ArrayElement[T]{{x, 0, a[0][x]}}, a.Ray(x, 0, 0, 1)...)
+}
+
+// All lines through the array in direction dx,dy (each ordered in that
+// direction) - eg. rows for 1,0, columns for 0,1, diagonals for 1,1 & 1,-1
+// (panics if dx,dy is 0,0)
+func (a Array[T]) Lines(dx, dy int) (out [][]ArrayElement[T]) {
+	checkStep(dx, dy)
+	a.Each(func(e ArrayElement[T]) {
+		if !a.inside(e.X-dx, e.Y-dy) {
+			out = append(out, append([]ArrayElement[T]{e}, a.Ray(e.X, e.Y, dx, dy)...))
+		}
+	})
+	return
+}
+
+// Cells visible from the edge in direction dx,dy (ie. every cell between the
+// cell and the edge is less than it)
+func (a Array[T]) Visible(dx, dy int, less func(a, b T) bool) Array[bool] {
+	out := a.mask()
+	for _, line := range a.Lines(dx, dy) {
+		var max T
+		for i := len(line) - 1; i >= 0; i-- {
+			e := line[i]
+			if i == len(line)-1 || less(max, e.Val) {
+				out[e.Y][e.X] = true
+				max = e.Val
+			}
+		}
+	}
+	return out
+}
+
+// Viewing distance in direction dx,dy - the number of cells up to and
+// including the first cell which is not less than the cell (or the edge)
+func (a Array[T]) ViewDistance(dx, dy int, less func(a, b T) bool) Array[int] {
+	out := make(Array[int], len(a))
+	for y := range a {
+		out[y] = make([]int, len(a[y]))
+	}
+	for _, line := range a.Lines(dx, dy) {
+		// Monotone stack of indexes (in line) of possible blocking cells
+		stack := []int{}
+		for i := len(line) - 1; i >= 0; i-- {
+			e := line[i]
+			for len(stack) > 0 && less(line[stack[len(stack)-1]].Val, e.Val) {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				out[e.Y][e.X] = len(line) - 1 - i
+			} else {
+				out[e.Y][e.X] = stack[len(stack)-1] - i
+			}
+			stack = append(stack, i)
+		}
+	}
+	return out
+}
+
+func (a Array[T]) mask() Array[bool] {
+	out := make(Array[bool], len(a))
+	for y := range a {
+		out[y] = make([]bool, len(a[y]))
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package array
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+var trees = Array[int]{
+	{3, 0, 3, 7, 3},
+	{2, 5, 5, 1, 2},
+	{6, 5, 3, 3, 2},
+	{3, 3, 5, 4, 9},
+	{3, 5, 3, 9, 0},
+}
+
+func values[T any](e []ArrayElement[T]) (out []T) {
+	for _, v := range e {
+		out = append(out, v.Val)
+	}
+	return
+}
+
+func TestArrayRay(t *testing.T) {
+	for _, v := range []struct {
+		x, y, dx, dy int
+		out          []int
+	}{
+		{2, 2, 1, 0, []int{3, 2}},
+		{2, 2, -1, -1, []int{5, 3}},
+		{2, 2, 1, -1, []int{1, 3}},
+		{0, 0, 0, -1, nil},
+		{0, 4, 1, -1, []int{3, 3, 1, 3}},
+	} {
+		if out := values(trees.Ray(v.x, v.y, v.dx, v.dy)); !slices.Equal(out, v.out) {
+			t.Error(v, out)
+		}
+	}
+	if out := values(trees.Row(1)); !slices.Equal(out, []int{2, 5, 5, 1, 2}) {
+		t.Error(out)
+	}
+	if out := values(trees.Column(1)); !slices.Equal(out, []int{0, 5, 5, 3, 5}) {
+		t.Error(out)
+	}
+}
+
+func TestArrayLines(t *testing.T) {
+	for _, v := range []struct{ dx, dy, n int }{{1, 0, 5}, {0, -1, 5}, {1, 1, 9}, {-1, 1, 9}} {
+		lines := trees.Lines(v.dx, v.dy)
+		count := 0
+		for _, l := range lines {
+			count += len(l)
+		}
+		if len(lines) != v.n || count != 25 {
+			t.Error(v, len(lines), count)
+		}
+	}
+	if out := values(trees.Lines(-1, 0)[0]); !slices.Equal(out, []int{3, 7, 3, 0, 3}) {
+		t.Error(out)
+	}
+}
+
+func TestArrayZeroStep(t *testing.T) {
+	for _, f := range []func(){
+		func() { trees.Ray(1, 1, 0, 0) },
+		func() { trees.Lines(0, 0) },
+		func() { trees.Visible(0, 0, func(a, b int) bool { return a < b }) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "Invalid direction: 0,0" {
+					t.Error("Expected panic:", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestArrayVisible(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	count := 0
+	masks := []Array[bool]{}
+	for _, d := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
+		masks = append(masks, trees.Visible(d.dx, d.dy, less))
+	}
+	trees.Each(func(e ArrayElement[int]) {
+		for _, m := range masks {
+			if m[e.Y][e.X] {
+				count++
+				return
+			}
+		}
+	})
+	if count != 21 {
+		t.Error(count)
+	}
+	if !masks[2][1][2] || masks[0][1][2] {
+		t.Error(masks)
+	}
+}
+
+func TestArrayViewDistance(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	best := 0
+	dist := []Array[int]{}
+	for _, d := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
+		dist = append(dist, trees.ViewDistance(d.dx, d.dy, less))
+	}
+	trees.Each(func(e ArrayElement[int]) {
+		score := 1
+		for _, d := range dist {
+			score *= d[e.Y][e.X]
+		}
+		if score > best {
+			best = score
+		}
+	})
+	if best != 8 {
+		t.Error(best)
+	}
+	// Brute force check
+	for _, d := range []struct{ dx, dy int }{{1, 1}, {-1, 1}, {1, -1}, {-1, -1}, {1, 0}} {
+		vd := trees.ViewDistance(d.dx, d.dy, less)
+		trees.Each(func(e ArrayElement[int]) {
+			n := 0
+			for _, r := range trees.Ray(e.X, e.Y, d.dx, d.dy) {
+				n++
+				if r.Val >= e.Val {
+					break
+				}
+			}
+			if vd[e.Y][e.X] != n {
+				t.Error(d, e, vd[e.Y][e.X], n)
+			}
+		})
+	}
+}
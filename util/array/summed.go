@@ -0,0 +1,40 @@
+package array
+
+// Summed-area table for O(1) rectangle sums
+type SummedArea struct {
+	sums Array[int] // sums[y][x] = sum of a[0:y][0:x]
+}
+
+func NewSummedArea(a Array[int]) *SummedArea {
+	w, h := a.size()
+	s := &SummedArea{sums: make(Array[int], h+1)}
+	s.sums[0] = make([]int, w+1)
+	for y := 0; y < h; y++ {
+		s.sums[y+1] = make([]int, w+1)
+		for x := 0; x < w; x++ {
+			s.sums[y+1][x+1] = a[y][x] + s.sums[y][x+1] + s.sums[y+1][x] - s.sums[y][x]
+		}
+	}
+	return s
+}
+
+// Sum of rectangle x0,y0 -> x1,y1 (inclusive, clipped to the array)
+func (s *SummedArea) Sum(x0, y0, x1, y1 int) int {
+	h, w := len(s.sums)-1, len(s.sums[0])-1
+	x0, y0 = clip(x0, 0, w), clip(y0, 0, h)
+	x1, y1 = clip(x1+1, 0, w), clip(y1+1, 0, h)
+	if x1 <= x0 || y1 <= y0 {
+		return 0
+	}
+	return s.sums[y1][x1] - s.sums[y0][x1] - s.sums[y1][x0] + s.sums[y0][x0]
+}
+
+func clip(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
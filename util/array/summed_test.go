@@ -0,0 +1,27 @@
+package array
+
+import (
+	"testing"
+)
+
+func TestSummedArea(t *testing.T) {
+	a := Array[int]{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	s := NewSummedArea(a)
+	for _, v := range []struct{ x0, y0, x1, y1, sum int }{
+		{0, 0, 2, 2, 45},
+		{1, 1, 1, 1, 5},
+		{1, 0, 2, 1, 16},
+		{0, 2, 2, 2, 24},
+		{-5, -5, 0, 0, 1},
+		{2, 2, 10, 10, 9},
+		{2, 2, 1, 1, 0},
+		{5, 5, 6, 6, 0},
+	} {
+		if sum := s.Sum(v.x0, v.y0, v.x1, v.y1); sum != v.sum {
+			t.Error(v, sum)
+		}
+	}
+	if sum := NewSummedArea(Array[int]{}).Sum(0, 0, 1, 1); sum != 0 {
+		t.Error(sum)
+	}
+}
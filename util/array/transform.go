@@ -0,0 +1,83 @@
+package array
+
+import (
+	"errors"
+)
+
+// Create new array (w x h) where f maps each x,y in the new array to the
+// source x,y in a
+func (a Array[T]) transform(w, h int, f func(x, y int) (int, int)) Array[T] {
+	out := make(Array[T], h)
+	for y := 0; y < h; y++ {
+		out[y] = make([]T, w)
+		for x := 0; x < w; x++ {
+			x1, y1 := f(x, y)
+			out[y][x] = a[y1][x1]
+		}
+	}
+	return out
+}
+
+func (a Array[T]) size() (w, h int) {
+	if len(a) == 0 {
+		return 0, 0
+	}
+	return len(a[0]), len(a)
+}
+
+// Rotate clockwise
+func (a Array[T]) Rotate90() Array[T] {
+	w, h := a.size()
+	return a.transform(h, w, func(x, y int) (int, int) { return y, h - 1 - x })
+}
+
+func (a Array[T]) Rotate180() Array[T] {
+	w, h := a.size()
+	return a.transform(w, h, func(x, y int) (int, int) { return w - 1 - x, h - 1 - y })
+}
+
+func (a Array[T]) Rotate270() Array[T] {
+	w, h := a.size()
+	return a.transform(h, w, func(x, y int) (int, int) { return w - 1 - y, x })
+}
+
+// Mirror left/right
+func (a Array[T]) FlipH() Array[T] {
+	w, h := a.size()
+	return a.transform(w, h, func(x, y int) (int, int) { return w - 1 - x, y })
+}
+
+// Mirror top/bottom
+func (a Array[T]) FlipV() Array[T] {
+	w, h := a.size()
+	return a.transform(w, h, func(x, y int) (int, int) { return x, h - 1 - y })
+}
+
+// All 8 rotations/reflections of a (the first is an unchanged copy)
+func (a Array[T]) Symmetries() []Array[T] {
+	w, h := a.size()
+	return []Array[T]{
+		a.Copy(),
+		a.Rotate90(),
+		a.Rotate180(),
+		a.Rotate270(),
+		a.FlipH(),
+		a.FlipV(),
+		a.Transpose(),
+		a.transform(h, w, func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }),
+	}
+}
+
+// Zero-copy view of the region x0,y0 -> x1,y1 (inclusive). The view is
+// re-indexed from 0,0 and rows are capped so writes can't overrun into a.
+func (a Array[T]) SubGrid(x0, y0, x1, y1 int) (Array[T], error) {
+	w, h := a.size()
+	if x0 < 0 || y0 < 0 || x1 >= w || y1 >= h || x1 < x0 || y1 < y0 {
+		return nil, errors.New("Invalid bounds")
+	}
+	out := make(Array[T], y1-y0+1)
+	for y := range out {
+		out[y] = a[y0+y][x0 : x1+1 : x1+1]
+	}
+	return out, nil
+}
@@ -0,0 +1,63 @@
+package array
+
+import (
+	"testing"
+)
+
+func TestArrayRotateFlip(t *testing.T) {
+	a := Array[int]{{1, 2, 3}, {4, 5, 6}}
+	eq := func(a, b int) bool { return a == b }
+	for _, v := range []struct {
+		in  Array[int]
+		out Array[int]
+	}{
+		{a.Rotate90(), Array[int]{{4, 1}, {5, 2}, {6, 3}}},
+		{a.Rotate180(), Array[int]{{6, 5, 4}, {3, 2, 1}}},
+		{a.Rotate270(), Array[int]{{3, 6}, {2, 5}, {1, 4}}},
+		{a.FlipH(), Array[int]{{3, 2, 1}, {6, 5, 4}}},
+		{a.FlipV(), Array[int]{{4, 5, 6}, {1, 2, 3}}},
+		{a.Rotate90().Rotate270(), a},
+		{Array[int]{}.Rotate90(), Array[int]{}},
+	} {
+		if !v.in.EqualFunc(v.out, eq) {
+			t.Error(v.in, v.out)
+		}
+	}
+	s := a.Symmetries()
+	if len(s) != 8 || !s[7].EqualFunc(Array[int]{{6, 3}, {5, 2}, {4, 1}}, eq) {
+		t.Error(s)
+	}
+}
+
+func TestArraySubGrid(t *testing.T) {
+	a := Array[int]{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	sub, err := a.SubGrid(1, 1, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sub.EqualFunc(Array[int]{{5, 6}, {8, 9}}, func(a, b int) bool { return a == b }) {
+		t.Error(sub)
+	}
+	sub.Set(0, 0, 99)
+	if a[1][1] != 99 {
+		t.Error(a)
+	}
+	// Appending to a row in the view must not overwrite the parent
+	sub[0] = append(sub[0], 100)
+	if a[2][0] != 7 {
+		t.Error(a)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic")
+			}
+		}()
+		sub.Set(2, 1, 0)
+	}()
+	for _, v := range []struct{ x0, y0, x1, y1 int }{{-1, 0, 2, 2}, {0, 0, 3, 2}, {2, 2, 1, 1}} {
+		if _, err := a.SubGrid(v.x0, v.y0, v.x1, v.y1); err == nil {
+			t.Error("Expected error", v)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package automaton
+
+import (
+	"fmt"
+
+	"github.com/paulc/aoc2022/util/grid"
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+	"golang.org/x/exp/slices"
+)
+
+// Neighbourhood of a cell
+type Neighbourhood func(p point.Point) []point.Point
+
+var (
+	VonNeumann Neighbourhood = point.Point.Adjacent
+	Moore      Neighbourhood = point.Point.AdjacentDiagonal
+)
+
+type Options[S any] struct {
+	MaxSteps int                          // Stop after MaxSteps (0 => unlimited)
+	OnStep   func(step int, state S) bool // Called after each step - return false to stop
+}
+
+// Run step function until the state is steady (step returns false), MaxSteps
+// is reached or OnStep returns false. Returns the final state, the number of
+// steps run (including the final steady step) and whether the state is steady.
+func Run[S any](state S, step func(S) (S, bool), opts Options[S]) (S, int, bool) {
+	for n := 1; opts.MaxSteps == 0 || n <= opts.MaxSteps; n++ {
+		next, changed := step(state)
+		state = next
+		if !changed {
+			return state, n, true
+		}
+		if opts.OnStep != nil && !opts.OnStep(n, state) {
+			return state, n, false
+		}
+	}
+	return state, opts.MaxSteps, false
+}
+
+// Synchronous update - each cell is updated from the previous state of its
+// neighbours (neighbours are mapped using the grid topology)
+func StepGrid[T comparable](g *grid.Grid[T], nbhd Neighbourhood, rule func(p point.Point, v T, neighbours []T) T) (*grid.Grid[T], bool, error) {
+	out, err := g.Copy()
+	if err != nil {
+		return nil, false, err
+	}
+	changed := false
+	for y := g.Y0; y <= g.Y1; y++ {
+		for x := g.X0; x <= g.X1; x++ {
+			p := point.Point{x, y}
+			neighbours := []T{}
+			for _, p1 := range nbhd(p) {
+				if p2, ok := g.Map(p, p1); ok {
+					neighbours = append(neighbours, g.Get(p2))
+				}
+			}
+			v := g.Get(p)
+			if v1 := rule(p, v, neighbours); v1 != v {
+				out.Set(p, v1)
+				changed = true
+			}
+		}
+	}
+	return out, changed, nil
+}
+
+// Synchronous update of sparse set of live cells - rule is called for each
+// live cell and its neighbours with the number of live neighbours
+func StepSet(s set.Set[point.Point], nbhd Neighbourhood, rule func(p point.Point, alive bool, n int) bool) (set.Set[point.Point], bool) {
+	count := make(map[point.Point]int)
+	for p := range s {
+		if _, ok := count[p]; !ok {
+			count[p] = 0
+		}
+		for _, p1 := range nbhd(p) {
+			count[p1]++
+		}
+	}
+	out := set.NewSet[point.Point]()
+	changed := false
+	for p, n := range count {
+		alive := s.Has(p)
+		if rule(p, alive, n) {
+			out.Add(p)
+		}
+		if out.Has(p) != alive {
+			changed = true
+		}
+	}
+	return out, changed
+}
+
+// Resolve conflicting proposals - returns the cell allowed to move to dest
+// (if any), which must be one of from
+type Resolve func(dest point.Point, from []point.Point) (point.Point, bool)
+
+// Default conflict resolution - nobody moves
+func NoneMove(dest point.Point, from []point.Point) (point.Point, bool) {
+	if len(from) == 1 {
+		return from[0], true
+	}
+	return point.Point{}, false
+}
+
+type proposals map[point.Point][]point.Point
+
+// Resolve proposals - moves into an occupied cell are blocked unless the
+// occupant also moves (repeated as blocked cells stay put). Panics if resolve
+// picks a cell which didn't propose dest.
+func (m proposals) resolve(resolve Resolve, occupied func(point.Point) bool) map[point.Point]point.Point {
+	if resolve == nil {
+		resolve = NoneMove
+	}
+	out := make(map[point.Point]point.Point)
+	for dest, from := range m {
+		p, ok := resolve(dest, from)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(from, p) {
+			panic(fmt.Sprintf("Resolve returned %v which did not propose %v", p, dest))
+		}
+		if p != dest {
+			out[p] = dest
+		}
+	}
+	for blocked := true; blocked; {
+		blocked = false
+		for p, dest := range out {
+			if _, moving := out[dest]; occupied(dest) && !moving {
+				delete(out, p)
+				blocked = true
+			}
+		}
+	}
+	return out
+}
+
+// Proposal update of sparse set - each cell may propose a destination and
+// conflicts are resolved by resolve (nil => NoneMove). Moves into a cell
+// which stays put are blocked. Returns the new state and the number of
+// proposals/moves.
+func ProposeSet(s set.Set[point.Point], propose func(p point.Point, s set.Set[point.Point]) (point.Point, bool), resolve Resolve) (out set.Set[point.Point], proposed, moved int) {
+	m := make(proposals)
+	for p := range s {
+		if dest, ok := propose(p, s); ok {
+			m[dest] = append(m[dest], p)
+			proposed++
+		}
+	}
+	moves := m.resolve(resolve, s.Has)
+	out = set.NewSet[point.Point]()
+	for p := range s {
+		if dest, ok := moves[p]; ok {
+			out.Add(dest)
+			moved++
+		} else {
+			out.Add(p)
+		}
+	}
+	return
+}
+
+// Proposal update of grid - cells may propose moving their value to dest
+// (leaving empty behind). Conflicts are resolved by resolve (nil => NoneMove)
+// and moves into a non-empty cell which stays put are blocked.
+func ProposeGrid[T comparable](g *grid.Grid[T], empty T, propose func(p point.Point, v T, g *grid.Grid[T]) (point.Point, bool), resolve Resolve) (out *grid.Grid[T], proposed, moved int, err error) {
+	m := make(proposals)
+	for y := g.Y0; y <= g.Y1; y++ {
+		for x := g.X0; x <= g.X1; x++ {
+			p := point.Point{x, y}
+			if dest, ok := propose(p, g.Get(p), g); ok && g.CheckBounds(dest) {
+				m[dest] = append(m[dest], p)
+				proposed++
+			}
+		}
+	}
+	if out, err = g.Copy(); err != nil {
+		return nil, 0, 0, err
+	}
+	moves := m.resolve(resolve, func(p point.Point) bool { return g.Get(p) != empty })
+	for p := range moves {
+		out.Set(p, empty)
+	}
+	for p, dest := range moves {
+		out.Set(dest, g.Get(p))
+		moved++
+	}
+	return
+}
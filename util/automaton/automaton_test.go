@@ -0,0 +1,189 @@
+package automaton
+
+import (
+	"testing"
+
+	"github.com/paulc/aoc2022/util/grid"
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+	"golang.org/x/exp/slices"
+)
+
+func life(p point.Point, alive bool, n int) bool {
+	return n == 3 || (alive && n == 2)
+}
+
+func lifeGrid(p point.Point, v bool, neighbours []bool) bool {
+	n := 0
+	for _, v := range neighbours {
+		if v {
+			n++
+		}
+	}
+	return life(p, v, n)
+}
+
+func TestStepSet(t *testing.T) {
+	blinker := set.NewSetFrom([]point.Point{{0, -1}, {0, 0}, {0, 1}})
+	s, changed := StepSet(blinker, Moore, life)
+	if !changed || !s.Equals(set.NewSetFrom([]point.Point{{-1, 0}, {0, 0}, {1, 0}})) {
+		t.Error(s)
+	}
+	s, _ = StepSet(s, Moore, life)
+	if !s.Equals(blinker) {
+		t.Error(s)
+	}
+	block := set.NewSetFrom([]point.Point{{0, 0}, {0, 1}, {1, 0}, {1, 1}})
+	if s, changed := StepSet(block, Moore, life); changed || !s.Equals(block) {
+		t.Error(s)
+	}
+}
+
+func TestStepGrid(t *testing.T) {
+	g, err := grid.NewGrid[bool](0, 0, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []point.Point{{2, 1}, {2, 2}, {2, 3}} {
+		g.Set(p, true)
+	}
+	g1, changed, err := StepGrid(g, Moore, lifeGrid)
+	if err != nil || !changed || !g1.Get(point.Point{1, 2}) || g1.Get(point.Point{2, 1}) {
+		t.Error(g1)
+	}
+	// Original unchanged
+	if !g.Get(point.Point{2, 1}) {
+		t.Error(g)
+	}
+	// Wrapping topology - blinker on the edge
+	g2, err := grid.NewGrid[bool](0, 0, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2.SetTopology(grid.Torus)
+	for _, p := range []point.Point{{0, 1}, {0, 2}, {0, 3}} {
+		g2.Set(p, true)
+	}
+	if g2, _, err = StepGrid(g2, Moore, lifeGrid); err != nil || !g2.Get(point.Point{4, 2}) || !g2.Get(point.Point{1, 2}) {
+		t.Error(g2)
+	}
+}
+
+func TestRun(t *testing.T) {
+	step := func(i int) (int, bool) {
+		if i < 5 {
+			return i + 1, true
+		}
+		return i, false
+	}
+	if v, n, steady := Run(0, step, Options[int]{}); v != 5 || n != 6 || !steady {
+		t.Error(v, n, steady)
+	}
+	if v, n, steady := Run(0, step, Options[int]{MaxSteps: 3}); v != 3 || n != 3 || steady {
+		t.Error(v, n, steady)
+	}
+	seen := []int{}
+	onStep := func(n, v int) bool {
+		seen = append(seen, v)
+		return n < 2
+	}
+	if v, n, steady := Run(0, step, Options[int]{OnStep: onStep}); v != 2 || n != 2 || steady {
+		t.Error(v, n, steady)
+	}
+	if !slices.Equal(seen, []int{1, 2}) {
+		t.Error(seen)
+	}
+}
+
+func TestProposeSet(t *testing.T) {
+	// Everyone moves right unless blocked - two cells contend for {1,0}
+	s := set.NewSetFrom([]point.Point{{0, 0}, {5, 5}})
+	right := func(p point.Point, s set.Set[point.Point]) (point.Point, bool) {
+		return p.Move(1, 0), !s.Has(p.Move(1, 0))
+	}
+	s, proposed, moved := ProposeSet(s, right, nil)
+	if proposed != 2 || moved != 2 || !s.Equals(set.NewSetFrom([]point.Point{{1, 0}, {6, 5}})) {
+		t.Error(s, proposed, moved)
+	}
+	s = set.NewSetFrom([]point.Point{{0, 0}, {2, 0}})
+	toward := func(p point.Point, s set.Set[point.Point]) (point.Point, bool) { return point.Point{1, 0}, true }
+	s, proposed, moved = ProposeSet(s, toward, nil)
+	if proposed != 2 || moved != 0 || s.Len() != 2 {
+		t.Error(s, proposed, moved)
+	}
+	// Left-most wins
+	first := func(dest point.Point, from []point.Point) (point.Point, bool) {
+		slices.SortFunc(from, func(a, b point.Point) bool { return a.X < b.X })
+		return from[0], true
+	}
+	s, _, moved = ProposeSet(s, toward, first)
+	if moved != 1 || !s.Equals(set.NewSetFrom([]point.Point{{1, 0}, {2, 0}})) {
+		t.Error(s, moved)
+	}
+	// Blocked by a cell which stays put (and a chain behind it) but can
+	// follow a cell which moves
+	always := func(p point.Point, s set.Set[point.Point]) (point.Point, bool) { return p.Move(1, 0), p.X < 2 }
+	s, proposed, moved = ProposeSet(set.NewSetFrom([]point.Point{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {1, 1}}), always, nil)
+	if proposed != 4 || moved != 2 || !s.Equals(set.NewSetFrom([]point.Point{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {2, 1}})) {
+		t.Error(s, proposed, moved)
+	}
+	// Resolve must pick one of the proposing cells
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic")
+			}
+		}()
+		ProposeSet(s, toward, func(dest point.Point, from []point.Point) (point.Point, bool) { return point.Point{9, 9}, true })
+	}()
+}
+
+func TestProposeGrid(t *testing.T) {
+	g, err := grid.NewGrid[byte](0, 0, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range g.Data {
+		g.Data[i] = '.'
+	}
+	g.Set(point.Point{0, 0}, 'o')
+	g.Set(point.Point{2, 0}, 'o')
+	g.Set(point.Point{1, 1}, '#')
+	fall := func(p point.Point, v byte, g *grid.Grid[byte]) (point.Point, bool) {
+		return p.Move(0, 1), v == 'o' && g.CheckBounds(p.Move(0, 1)) && g.Get(p.Move(0, 1)) == '.'
+	}
+	g, n, steady := Run(g, func(g *grid.Grid[byte]) (*grid.Grid[byte], bool) {
+		g1, _, moved, err := ProposeGrid(g, '.', fall, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g1, moved > 0
+	}, Options[*grid.Grid[byte]]{})
+	if string(g.Data) != "....#.o.o" || n != 3 || !steady {
+		t.Errorf("%d %q", n, g.Data)
+	}
+	// Moves into cells which stay put are blocked (also blocking moves into
+	// the blocked cell) but a cell may move into one being vacated
+	right := func(p point.Point, v byte, g *grid.Grid[byte]) (point.Point, bool) {
+		return p.Move(1, 0), v != '.' && v != '#' && g.CheckBounds(p.Move(1, 0))
+	}
+	for _, v := range []struct{ in, out string }{{"ab#", "ab#"}, {"ab.", ".ab"}, {"abc", "abc"}, {"a.b", ".ab"}} {
+		g, err := grid.NewGrid[byte](0, 0, 2, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		copy(g.Data, v.in)
+		g1, _, _, err := ProposeGrid(g, '.', right, nil)
+		if err != nil || string(g1.Data[:3]) != v.out {
+			t.Errorf("%s: %q %v", v.in, g1.Data[:3], err)
+		}
+	}
+	// Single row grid (SubGrid view)
+	sub, err := g.SubGrid(0, 2, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := ProposeGrid(sub, '.', fall, nil); err != nil {
+		t.Error(err)
+	}
+}
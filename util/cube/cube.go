@@ -0,0 +1,218 @@
+package cube
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// Edges are indexed by the direction of travel that crosses them
+type Edge struct {
+	Face int
+	Edge point.Dir
+}
+
+type Face[T any] struct {
+	Tiles array.Array[T] // View into the net
+	X, Y  int            // Position of top-left tile in the net
+	Edges map[point.Dir]Edge
+	n     vec // Outward normal
+	r, d  vec // Directions of +x/+y on the face
+}
+
+type Cube[T any] struct {
+	Size  int
+	Faces [6]Face[T]
+}
+
+type Pos struct {
+	Face, X, Y int
+	Dir        point.Dir
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("Face: %d (%d,%d) %s", p.Face, p.X, p.Y, p.Dir)
+}
+
+type vec struct{ x, y, z int }
+
+func (v vec) neg() vec {
+	return vec{-v.x, -v.y, -v.z}
+}
+
+func (v vec) add(v2 vec) vec {
+	return vec{v.x + v2.x, v.y + v2.y, v.z + v2.z}
+}
+
+func (v vec) scale(n int) vec {
+	return vec{v.x * n, v.y * n, v.z * n}
+}
+
+func (v vec) dot(v2 vec) int {
+	return v.x*v2.x + v.y*v2.y + v.z*v2.z
+}
+
+// Direction of travel on face in 3D
+func (f *Face[T]) dir(d point.Dir) vec {
+	switch d {
+	case point.E:
+		return f.r
+	case point.S:
+		return f.d
+	case point.W:
+		return f.r.neg()
+	case point.N:
+		return f.d.neg()
+	}
+	panic(fmt.Sprintf("Invalid direction: %s", d))
+}
+
+// Fold cube net (any of the 11 nets) - tiles where void is true are outside
+// the net. Rows may be ragged.
+func Fold[T any](net array.Array[T], void func(T) bool) (*Cube[T], error) {
+	inNet := func(x, y int) bool {
+		return y >= 0 && y < len(net) && x >= 0 && x < len(net[y]) && !void(net[y][x])
+	}
+	count, w := 0, 0
+	for y := range net {
+		for x := range net[y] {
+			if inNet(x, y) {
+				count++
+			}
+		}
+		if len(net[y]) > w {
+			w = len(net[y])
+		}
+	}
+	size := int(math.Sqrt(float64(count / 6)))
+	if size == 0 || count != 6*size*size {
+		return nil, fmt.Errorf("Invalid cube net: %d tiles", count)
+	}
+	c := &Cube[T]{Size: size}
+	// Find faces in reading order
+	faces := map[[2]int]int{}
+	for iy := 0; iy < len(net)/size; iy++ {
+		for ix := 0; ix < w/size; ix++ {
+			if inNet(ix*size, iy*size) {
+				if len(faces) == 6 {
+					return nil, errors.New("Invalid cube net: too many faces")
+				}
+				f := &c.Faces[len(faces)]
+				f.X, f.Y = ix*size, iy*size
+				f.Tiles = make(array.Array[T], size)
+				for y := 0; y < size; y++ {
+					if !inNet(f.X+size-1, f.Y+y) {
+						return nil, errors.New("Invalid cube net: incomplete face")
+					}
+					f.Tiles[y] = net[f.Y+y][f.X : f.X+size : f.X+size]
+				}
+				faces[[2]int{ix, iy}] = len(faces)
+			}
+		}
+	}
+	if len(faces) != 6 {
+		return nil, errors.New("Invalid cube net: faces not aligned")
+	}
+	// Fold net - crossing an edge rolls the cube so the new face normal is
+	// the direction of travel and the new direction of travel is -normal
+	c.Faces[0].n, c.Faces[0].r, c.Faces[0].d = vec{0, 0, 1}, vec{1, 0, 0}, vec{0, 1, 0}
+	seen := map[int]bool{0: true}
+	queue := []int{0}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		f := c.Faces[i]
+		ix, iy := f.X/size, f.Y/size
+		for _, v := range []struct {
+			dx, dy  int
+			n, r, d vec
+		}{
+			{1, 0, f.r, f.n.neg(), f.d},
+			{0, 1, f.d, f.r, f.n.neg()},
+			{-1, 0, f.r.neg(), f.n, f.d},
+			{0, -1, f.d.neg(), f.r, f.n},
+		} {
+			if j, ok := faces[[2]int{ix + v.dx, iy + v.dy}]; ok && !seen[j] {
+				seen[j] = true
+				c.Faces[j].n, c.Faces[j].r, c.Faces[j].d = v.n, v.r, v.d
+				queue = append(queue, j)
+			}
+		}
+	}
+	if len(seen) != 6 {
+		return nil, errors.New("Invalid cube net: faces not connected")
+	}
+	// Build edge table
+	for i := range c.Faces {
+		c.Faces[i].Edges = make(map[point.Dir]Edge)
+		for _, d := range point.Dirs4 {
+			j, ok := c.faceWithNormal(c.Faces[i].dir(d))
+			if !ok {
+				return nil, errors.New("Invalid cube net: faces overlap")
+			}
+			for _, e := range point.Dirs4 {
+				// Edge on the adjacent face which leads back to this face
+				if c.Faces[j].dir(e) == c.Faces[i].n {
+					c.Faces[i].Edges[d] = Edge{j, e}
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *Cube[T]) faceWithNormal(n vec) (int, bool) {
+	for i := range c.Faces {
+		if c.Faces[i].n == n {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Position in the original net
+func (c *Cube[T]) NetPos(p Pos) (x, y int) {
+	return c.Faces[p.Face].X + p.X, c.Faces[p.Face].Y + p.Y
+}
+
+func (c *Cube[T]) Get(p Pos) T {
+	return c.Faces[p.Face].Tiles[p.Y][p.X]
+}
+
+// Single step in current direction (crossing to the adjacent face if needed)
+func (c *Cube[T]) Step(p Pos) Pos {
+	dx, dy := p.Dir.Delta()
+	x, y := p.X+dx, p.Y+dy
+	if x >= 0 && x < c.Size && y >= 0 && y < c.Size {
+		return Pos{p.Face, x, y, p.Dir}
+	}
+	// Use doubled 3D coordinates (cube centred on origin) - crossing an edge
+	// moves one unit along the new face's normal (out past the edge) and one
+	// unit inward along the old face's normal (down onto the new face)
+	f := &c.Faces[p.Face]
+	pos := f.n.scale(c.Size).add(f.r.scale(2*p.X + 1 - c.Size)).add(f.d.scale(2*p.Y + 1 - c.Size))
+	e := f.Edges[p.Dir]
+	next := &c.Faces[e.Face]
+	pos = pos.add(next.n).add(f.n.neg())
+	return Pos{
+		Face: e.Face,
+		X:    (pos.dot(next.r) + c.Size - 1) / 2,
+		Y:    (pos.dot(next.d) + c.Size - 1) / 2,
+		Dir:  e.Edge.Reverse(),
+	}
+}
+
+// Move up to n steps stopping before blocked tiles
+func (c *Cube[T]) Move(p Pos, n int, blocked func(T) bool) Pos {
+	for i := 0; i < n; i++ {
+		next := c.Step(p)
+		if blocked(c.Get(next)) {
+			break
+		}
+		p = next
+	}
+	return p
+}
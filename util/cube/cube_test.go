@@ -0,0 +1,118 @@
+package cube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// The 11 cube nets (one char per face)
+var nets = []string{
+	"#...\n####\n#...",
+	"#...\n####\n.#..",
+	"#...\n####\n..#.",
+	"#...\n####\n...#",
+	".#..\n####\n.#..",
+	".#..\n####\n..#.",
+	"##..\n.###\n.#..",
+	"##..\n.###\n..#.",
+	"##..\n.###\n...#",
+	"##..\n.##.\n..##",
+	"###..\n..###",
+}
+
+// Expand net so each face is size x size (ragged rows)
+func makeNet(net string, size int) (out array.Array[byte]) {
+	for _, line := range strings.Split(net, "\n") {
+		line = strings.TrimRight(line, ".")
+		row := []byte{}
+		for _, c := range []byte(line) {
+			row = append(row, []byte(strings.Repeat(string(c), size))...)
+		}
+		for i := 0; i < size; i++ {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func isVoid(b byte) bool { return b == '.' }
+
+func TestFoldNets(t *testing.T) {
+	for _, net := range nets {
+		for _, size := range []int{1, 3, 4} {
+			c, err := Fold(makeNet(net, size), isVoid)
+			if err != nil {
+				t.Fatal(net, size, err)
+			}
+			if c.Size != size {
+				t.Error(net, c.Size)
+			}
+			// Edge table is symmetric and each face has 4 distinct neighbours
+			for i, f := range c.Faces {
+				seen := map[int]bool{}
+				for d, e := range f.Edges {
+					if e.Face == i || seen[e.Face] {
+						t.Error(net, i, f.Edges)
+					}
+					seen[e.Face] = true
+					if back := c.Faces[e.Face].Edges[e.Edge]; back != (Edge{i, d}) {
+						t.Error(net, i, d, e, back)
+					}
+				}
+			}
+			// Walking 4 * size in any direction returns to the start
+			for i := range c.Faces {
+				for y := 0; y < size; y++ {
+					for x := 0; x < size; x++ {
+						for _, d := range point.Dirs4 {
+							start := Pos{i, x, y, d}
+							p := c.Move(start, 4*size, func(byte) bool { return false })
+							if p != start {
+								t.Error(net, size, start, p)
+							}
+							// Step and reverse
+							p = c.Step(start)
+							p.Dir = p.Dir.Reverse()
+							p = c.Step(p)
+							if p.Face != start.Face || p.X != start.X || p.Y != start.Y {
+								t.Error(net, size, start, p)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFoldInvalid(t *testing.T) {
+	for _, net := range []string{
+		"####\n####",
+		"#...\n####\n#...\n#...",
+		"######",
+		"##\n#.\n...#\n...#",
+	} {
+		if _, err := Fold(makeNet(net, 2), isVoid); err == nil {
+			t.Error("Expected error:", net)
+		}
+	}
+}
+
+func TestCubeMove(t *testing.T) {
+	net := makeNet(nets[0], 2)
+	net[3] = []byte("#X######") // Block on face 2
+	c, err := Fold(net, isVoid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := c.Move(Pos{1, 0, 1, point.E}, 10, func(b byte) bool { return b == 'X' })
+	if p != (Pos{1, 0, 1, point.E}) {
+		t.Error(p)
+	}
+	if x, y := c.NetPos(Pos{2, 1, 1, point.E}); x != 3 || y != 3 {
+		t.Error(x, y)
+	}
+}
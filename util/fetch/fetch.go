@@ -0,0 +1,170 @@
+package fetch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultURL  = "https://adventofcode.com"
+	SessionEnv  = "AOC_SESSION"
+	CacheDirEnv = "AOC_CACHE"
+)
+
+var ErrNoSession = errors.New("No session cookie (set " + SessionEnv + ")")
+
+// Non-200 response
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Error fetching <%s>: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Puzzle input client. Inputs are cached in CacheDir (if set) and requests
+// are spaced by at least MinInterval.
+type Client struct {
+	BaseURL     string
+	Session     string
+	CacheDir    string
+	MinInterval time.Duration
+	UserAgent   string
+	HTTP        *http.Client
+	mu          sync.Mutex
+	last        time.Time
+}
+
+// Client configured from the environment - session from AOC_SESSION and
+// cache from AOC_CACHE (default <user cache dir>/aoc)
+func NewClient() *Client {
+	c := &Client{
+		BaseURL:     DefaultURL,
+		Session:     os.Getenv(SessionEnv),
+		CacheDir:    os.Getenv(CacheDirEnv),
+		MinInterval: 5 * time.Second,
+		UserAgent:   "github.com/paulc/aoc2022/util/fetch",
+		HTTP:        &http.Client{Timeout: 30 * time.Second},
+	}
+	if c.CacheDir == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			c.CacheDir = filepath.Join(dir, "aoc")
+		}
+	}
+	return c
+}
+
+func (c *Client) cachePath(year, day int) string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%02d.input", day))
+}
+
+// Wait until MinInterval has passed since the last request
+func (c *Client) wait() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d := time.Until(c.last.Add(c.MinInterval)); d > 0 {
+		time.Sleep(d)
+	}
+	c.last = time.Now()
+}
+
+// Input for year/day (from cache if available)
+func (c *Client) Input(year, day int) ([]byte, error) {
+	if c.CacheDir != "" {
+		if b, err := os.ReadFile(c.cachePath(year, day)); err == nil {
+			return b, nil
+		}
+	}
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.BaseURL, year, day)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c.wait()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching <%s>: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{url, resp.StatusCode}
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching <%s>: %w", url, err)
+	}
+	if c.CacheDir != "" {
+		if err := c.save(year, day, b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Write to cache (via a temp file so partial inputs are never cached)
+func (c *Client) save(year, day int, b []byte) error {
+	path := c.cachePath(year, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".input-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (c *Client) Open(year, day int) (io.ReadCloser, error) {
+	b, err := c.Input(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+var (
+	defaultClient     *Client
+	defaultClientOnce sync.Once
+)
+
+// Open local file if it exists, otherwise fetch the input for year/day
+// using a client configured from the environment
+func Open(file string, year, day int) (io.ReadCloser, error) {
+	f, err := os.Open(file)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	defaultClientOnce.Do(func() { defaultClient = NewClient() })
+	return defaultClient.Open(year, day)
+}
@@ -0,0 +1,121 @@
+package fetch
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/paulc/aoc2022/util/fetch/fetchtest"
+)
+
+func testClient(t *testing.T, url string) *Client {
+	return &Client{BaseURL: url, Session: "secret", CacheDir: t.TempDir(), HTTP: http.DefaultClient}
+}
+
+func TestInput(t *testing.T) {
+	srv := fetchtest.NewServer("secret", map[string]string{fetchtest.Key(2022, 1): "1\n2\n3\n"})
+	defer srv.Close()
+	c := testClient(t, srv.URL)
+	for i := 0; i < 2; i++ {
+		b, err := c.Input(2022, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "1\n2\n3\n" {
+			t.Error(string(b))
+		}
+	}
+	// Second request is cached
+	if srv.Requests() != 1 {
+		t.Error(srv.Requests())
+	}
+	if b, err := os.ReadFile(filepath.Join(c.CacheDir, "2022", "day01.input")); err != nil || string(b) != "1\n2\n3\n" {
+		t.Error(string(b), err)
+	}
+	r, err := c.Open(2022, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := io.ReadAll(r); string(b) != "1\n2\n3\n" {
+		t.Error(string(b))
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	srv := fetchtest.NewServer("secret", map[string]string{})
+	defer srv.Close()
+	c := testClient(t, srv.URL)
+	var se *StatusError
+	if _, err := c.Input(2022, 2); !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Error(err)
+	}
+	c.Session = "wrong"
+	if _, err := c.Input(2022, 2); !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized {
+		t.Error(err)
+	}
+	c.Session = ""
+	if _, err := c.Input(2022, 2); !errors.Is(err, ErrNoSession) {
+		t.Error(err)
+	}
+	// Errors aren't cached
+	entries, _ := os.ReadDir(c.CacheDir)
+	if len(entries) != 0 {
+		t.Error(entries)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := fetchtest.NewServer("secret", map[string]string{fetchtest.Key(2022, 1): "a", fetchtest.Key(2022, 2): "b", fetchtest.Key(2022, 3): "c"})
+	defer srv.Close()
+	c := testClient(t, srv.URL)
+	c.MinInterval = 50 * time.Millisecond
+	start := time.Now()
+	for day := 1; day <= 3; day++ {
+		if _, err := c.Input(2022, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 2*c.MinInterval {
+		t.Error("Requests not rate limited", d)
+	}
+}
+
+func TestNoCache(t *testing.T) {
+	srv := fetchtest.NewServer("secret", map[string]string{fetchtest.Key(2022, 1): "a"})
+	defer srv.Close()
+	c := testClient(t, srv.URL)
+	c.CacheDir = ""
+	for i := 0; i < 2; i++ {
+		if _, err := c.Input(2022, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if srv.Requests() != 2 {
+		t.Error(srv.Requests())
+	}
+}
+
+func TestOpenLocal(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(file, []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(file, 2022, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if b, _ := io.ReadAll(r); string(b) != "local" {
+		t.Error(string(b))
+	}
+	// Errors other than a missing file are returned (rather than fetching)
+	var pe *fs.PathError
+	if _, err := Open(filepath.Join(file, "input"), 2022, 1); !errors.As(err, &pe) {
+		t.Error(err)
+	}
+}
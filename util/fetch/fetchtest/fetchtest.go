@@ -0,0 +1,57 @@
+// Fake puzzle input server for testing util/fetch offline
+package fetchtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+type Server struct {
+	*httptest.Server
+	Session string
+	Inputs  map[string]string // Keyed by "<year>/<day>"
+	mu      sync.Mutex
+	count   int
+}
+
+// Start fake server - requests must have a session cookie matching session
+// (401 otherwise) and unknown inputs return 404
+func NewServer(session string, inputs map[string]string) *Server {
+	s := &Server{Session: session, Inputs: inputs}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func Key(year, day int) string {
+	return fmt.Sprintf("%d/%d", year, day)
+}
+
+// Number of requests received
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+	var year, day int
+	if _, err := fmt.Sscanf(r.URL.Path, "/%d/day/%d/input", &year, &day); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if c, err := r.Cookie("session"); err != nil || c.Value != s.Session {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusUnauthorized)
+		return
+	}
+	input, ok := s.Inputs[Key(year, day)]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	fmt.Fprint(w, input)
+}
@@ -0,0 +1,76 @@
+package grid
+
+import (
+	"github.com/paulc/aoc2022/util"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// Draw line from start to end (inclusive) using Bresenham's algorithm
+func (g *Grid[T]) DrawLine(start, end point.Point, val T) {
+	dx, dy := start.Xdistance(end), -start.Ydistance(end)
+	step := end.Sub(start).Sign()
+	err := dx + dy
+	for p := start; ; {
+		g.Set(p, val)
+		if p == end {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			p.X += step.X
+		}
+		if e2 <= dx {
+			err += dx
+			p.Y += step.Y
+		}
+	}
+}
+
+func (g *Grid[T]) DrawPolyline(path []point.Point, val T) {
+	for i := 0; i < len(path)-1; i++ {
+		g.DrawLine(path[i], path[i+1], val)
+	}
+	if len(path) == 1 {
+		g.Set(path[0], val)
+	}
+}
+
+// Draw rectangle outline with corners p1/p2
+func (g *Grid[T]) DrawRect(p1, p2 point.Point, val T) {
+	g.DrawPolyline([]point.Point{p1, {p2.X, p1.Y}, p2, {p1.X, p2.Y}, p1}, val)
+}
+
+func (g *Grid[T]) FillRect(p1, p2 point.Point, val T) {
+	for y := util.Min(p1.Y, p2.Y); y <= util.Max(p1.Y, p2.Y); y++ {
+		for x := util.Min(p1.X, p2.X); x <= util.Max(p1.X, p2.X); x++ {
+			g.Set(point.Point{x, y}, val)
+		}
+	}
+}
+
+// Draw circle outline using the midpoint circle algorithm
+func (g *Grid[T]) DrawCircle(c point.Point, r int, val T) {
+	x, y, err := r, 0, 1-r
+	for x >= y {
+		for _, v := range []struct{ dx, dy int }{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			g.Set(c.Move(v.dx, v.dy), val)
+		}
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+// Draw circle outline using Manhattan distance (diamond)
+func (g *Grid[T]) DrawCircleManhattan(c point.Point, r int, val T) {
+	for i := 0; i <= r; i++ {
+		for _, v := range []struct{ dx, dy int }{{i, r - i}, {-i, r - i}, {i, i - r}, {-i, i - r}} {
+			g.Set(c.Move(v.dx, v.dy), val)
+		}
+	}
+}
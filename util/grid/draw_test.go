@@ -0,0 +1,73 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+)
+
+type _pixel bool
+
+func (p _pixel) String() string {
+	if p {
+		return "#"
+	}
+	return "."
+}
+
+func TestGridDrawLine(t *testing.T) {
+	for _, v := range []struct {
+		start, end point.Point
+		out        string
+	}{
+		{point.Point{1, 1}, point.Point{3, 1}, ".....\n.###.\n.....\n....."},
+		{point.Point{3, 3}, point.Point{3, 0}, "...#.\n...#.\n...#.\n...#."},
+		{point.Point{0, 0}, point.Point{3, 3}, "#....\n.#...\n..#..\n...#."},
+		{point.Point{4, 0}, point.Point{1, 3}, "....#\n...#.\n..#..\n.#..."},
+		{point.Point{0, 0}, point.Point{4, 2}, "#....\n.##..\n...##\n....."},
+		{point.Point{0, 3}, point.Point{1, 0}, ".#...\n.#...\n#....\n#...."},
+		{point.Point{2, 2}, point.Point{2, 2}, ".....\n.....\n..#..\n....."},
+	} {
+		g := testGrid[_pixel](t, 0, 0, 4, 3)
+		g.DrawLine(v.start, v.end, true)
+		if g.String() != v.out {
+			t.Errorf("%v -> %v\n%s", v.start, v.end, g)
+		}
+	}
+}
+
+func TestGridDrawShapes(t *testing.T) {
+	g := testGrid[_pixel](t, 0, 0, 4, 3)
+	g.DrawPolyline([]point.Point{{0, 0}, {4, 0}, {4, 3}}, true)
+	if g.String() != "#####\n....#\n....#\n....#" {
+		t.Errorf("\n%s", g)
+	}
+	g = testGrid[_pixel](t, 0, 0, 4, 3)
+	g.DrawRect(point.Point{3, 3}, point.Point{1, 1}, true)
+	if g.String() != ".....\n.###.\n.#.#.\n.###." {
+		t.Errorf("\n%s", g)
+	}
+	g = testGrid[_pixel](t, 0, 0, 4, 3)
+	g.FillRect(point.Point{3, 3}, point.Point{2, 1}, true)
+	if g.String() != ".....\n..##.\n..##.\n..##." {
+		t.Errorf("\n%s", g)
+	}
+	g = testGrid[_pixel](t, -4, -4, 0, 0)
+	g.FillRect(point.Point{-3, -3}, point.Point{-2, -2}, true)
+	if g.String() != ".....\n.##..\n.##..\n.....\n....." {
+		t.Errorf("\n%s", g)
+	}
+}
+
+func TestGridDrawCircle(t *testing.T) {
+	g := testGrid[_pixel](t, -3, -3, 3, 3)
+	g.DrawCircle(point.Point{0, 0}, 3, true)
+	if g.String() != "..###..\n.#...#.\n#.....#\n#.....#\n#.....#\n.#...#.\n..###.." {
+		t.Errorf("\n%s", g)
+	}
+	g = testGrid[_pixel](t, -3, -3, 3, 3)
+	g.DrawCircleManhattan(point.Point{0, 0}, 3, true)
+	if g.String() != "...#...\n..#.#..\n.#...#.\n#.....#\n.#...#.\n..#.#..\n...#..." {
+		t.Errorf("\n%s", g)
+	}
+}
@@ -0,0 +1,100 @@
+package grid
+
+import (
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+)
+
+type Region struct {
+	ID        int
+	Start     point.Point
+	Size      int
+	Perimeter int
+}
+
+func (g *Grid[T]) AdjacentDiagonal(p point.Point) (out []point.Point) {
+	for _, p1 := range p.AdjacentDiagonal() {
+		if p2, ok := g.mapPoint(p, p1, Bounded); ok {
+			out = append(out, p2)
+		}
+	}
+	return
+}
+
+// Iterative flood fill from start (4-connected) - returns the set of points
+// reached where f is true
+func (g *Grid[T]) FloodFill(start point.Point, f func(point.Point, T) bool) set.Set[point.Point] {
+	return g.floodFill(start, f, g.Adjacent)
+}
+
+// Flood fill including diagonals (8-connected)
+func (g *Grid[T]) FloodFillDiagonal(start point.Point, f func(point.Point, T) bool) set.Set[point.Point] {
+	return g.floodFill(start, f, g.AdjacentDiagonal)
+}
+
+func (g *Grid[T]) floodFill(start point.Point, f func(point.Point, T) bool, adjacent func(point.Point) []point.Point) set.Set[point.Point] {
+	out := set.NewSet[point.Point]()
+	if !g.CheckBounds(start) || !f(start, g.Get(start)) {
+		return out
+	}
+	out.Add(start)
+	stack := []point.Point{start}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p1 := range adjacent(p) {
+			if !out.Has(p1) && f(p1, g.Get(p1)) {
+				out.Add(p1)
+				stack = append(stack, p1)
+			}
+		}
+	}
+	return out
+}
+
+// Label connected regions (4-connected) of cells where same(seed, cell) is
+// true - each cell is compared with the first (seed) cell of the region rather
+// than its neighbour so same should normally be an equivalence (eg. ==).
+// Returns a grid of region IDs (indexes into regions)
+func (g *Grid[T]) LabelRegions(same func(a, b T) bool) (*Grid[int], []Region) {
+	return g.labelRegions(same, g.Adjacent)
+}
+
+// Label connected regions including diagonals (8-connected)
+func (g *Grid[T]) LabelRegionsDiagonal(same func(a, b T) bool) (*Grid[int], []Region) {
+	return g.labelRegions(same, g.AdjacentDiagonal)
+}
+
+func (g *Grid[T]) labelRegions(same func(a, b T) bool, adjacent func(point.Point) []point.Point) (*Grid[int], []Region) {
+	labels := &Grid[int]{X0: g.X0, Y0: g.Y0, X1: g.X1, Y1: g.Y1, Width: g.Width, Height: g.Height}
+	labels.Data = make([]int, g.Width*g.Height)
+	for i := range labels.Data {
+		labels.Data[i] = -1
+	}
+	regions := []Region{}
+	for y := g.Y0; y <= g.Y1; y++ {
+		for x := g.X0; x <= g.X1; x++ {
+			p := point.Point{x, y}
+			if labels.Get(p) != -1 {
+				continue
+			}
+			v := g.Get(p)
+			r := Region{ID: len(regions), Start: p}
+			for p1 := range g.floodFill(p, func(p2 point.Point, v2 T) bool { return labels.Get(p2) == -1 && same(v, v2) }, adjacent) {
+				labels.Set(p1, r.ID)
+				r.Size++
+			}
+			regions = append(regions, r)
+		}
+	}
+	// Perimeter is always counted on cell edges
+	for i, id := range labels.Data {
+		p := point.Point{g.X0 + i%g.Width, g.Y0 + i/g.Width}
+		for _, p1 := range p.Adjacent() {
+			if !labels.CheckBounds(p1) || labels.Get(p1) != id {
+				regions[id].Perimeter++
+			}
+		}
+	}
+	return labels, regions
+}
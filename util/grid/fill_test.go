@@ -0,0 +1,70 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"github.com/paulc/aoc2022/util/set"
+)
+
+// 0 1 0 0
+// 1 1 0 1
+// 0 0 1 1
+func TestGridFloodFill(t *testing.T) {
+	g := testGrid(t, 0, 0, 3, 2, 0, 1, 0, 0, 1, 1, 0, 1, 0, 0, 1, 1)
+	zero := func(_ point.Point, v int) bool { return v == 0 }
+	one := func(_ point.Point, v int) bool { return v == 1 }
+	if f := g.FloodFill(point.Point{2, 0}, zero); !f.Equals(set.NewSetFrom([]point.Point{{2, 0}, {3, 0}, {2, 1}})) {
+		t.Error(f)
+	}
+	if f := g.FloodFill(point.Point{1, 0}, one); !f.Equals(set.NewSetFrom([]point.Point{{1, 0}, {0, 1}, {1, 1}})) {
+		t.Error(f)
+	}
+	if f := g.FloodFillDiagonal(point.Point{1, 0}, one); f.Len() != 6 {
+		t.Error(f)
+	}
+	if f := g.FloodFill(point.Point{1, 0}, zero); f.Len() != 0 {
+		t.Error(f)
+	}
+	if f := g.FloodFill(point.Point{-1, 0}, zero); f.Len() != 0 {
+		t.Error(f)
+	}
+}
+
+func TestGridFloodFillLarge(t *testing.T) {
+	g, err := NewGrid[bool](0, 0, 499, 499)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := g.FloodFill(point.Point{0, 0}, func(_ point.Point, v bool) bool { return !v }); f.Len() != 500*500 {
+		t.Error(f.Len())
+	}
+}
+
+func TestGridLabelRegions(t *testing.T) {
+	g := testGrid(t, 0, 0, 3, 2, 0, 1, 0, 0, 1, 1, 0, 1, 0, 0, 1, 1)
+	eq := func(a, b int) bool { return a == b }
+	labels, regions := g.LabelRegions(eq)
+	if len(regions) != 5 {
+		t.Fatal(regions)
+	}
+	for _, v := range []struct {
+		p               point.Point
+		size, perimeter int
+	}{
+		{point.Point{0, 0}, 1, 4},
+		{point.Point{1, 0}, 3, 8},
+		{point.Point{2, 0}, 3, 8},
+		{point.Point{3, 1}, 3, 8},
+		{point.Point{0, 2}, 2, 6},
+	} {
+		r := regions[labels.Get(v.p)]
+		if r.Size != v.size || r.Perimeter != v.perimeter {
+			t.Error(v, r)
+		}
+	}
+	_, regions = g.LabelRegionsDiagonal(eq)
+	if len(regions) != 3 {
+		t.Error(regions)
+	}
+}
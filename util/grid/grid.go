@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/paulc/aoc2022/util"
 	"github.com/paulc/aoc2022/util/point"
 	"golang.org/x/exp/slices"
 )
@@ -14,6 +13,9 @@ type Grid[T any] struct {
 	X0, Y0, X1, Y1 int
 	Width, Height  int
 	Data           []T
+	stride         int // Row stride for SubGrid views (0 => Width)
+	topology       Topology
+	strict         bool
 }
 
 func NewGrid[T any](x0, y0, x1, y1 int) (*Grid[T], error) {
@@ -27,45 +29,79 @@ func NewGrid[T any](x0, y0, x1, y1 int) (*Grid[T], error) {
 	return g, nil
 }
 
+func NewGridFromRect[T any](r point.Rect) (*Grid[T], error) {
+	return NewGrid[T](r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
+}
+
+// Grid bounds
+func (g *Grid[T]) Rect() point.Rect {
+	return point.NewRect(g.X0, g.Y0, g.X1, g.Y1)
+}
+
+// Copy grid (or SubGrid view) - built directly rather than with NewGrid as
+// views may be a single row/column
 func (g *Grid[T]) Copy() (*Grid[T], error) {
-	g2, err := NewGrid[T](g.X0, g.Y0, g.X1, g.Y1)
-	if err != nil {
-		return nil, err
+	g2 := &Grid[T]{X0: g.X0, Y0: g.Y0, X1: g.X1, Y1: g.Y1, Width: g.Width, Height: g.Height}
+	if g.stride == 0 {
+		g2.Data = slices.Clone(g.Data)
+	} else {
+		g2.Data = make([]T, g.Width*g.Height)
+		for y := 0; y < g.Height; y++ {
+			copy(g2.Data[y*g.Width:(y+1)*g.Width], g.Data[y*g.stride:y*g.stride+g.Width])
+		}
 	}
-	g2.Data = slices.Clone(g.Data)
+	g2.topology, g2.strict = g.topology, g.strict
 	return g2, nil
 }
 
+func (g *Grid[T]) index(p point.Point) int {
+	if g.stride == 0 {
+		return (p.X - g.X0) + (p.Y-g.Y0)*g.Width
+	}
+	return (p.X - g.X0) + (p.Y-g.Y0)*g.stride
+}
+
 func (g *Grid[T]) CheckBounds(p point.Point) bool {
-	return !(p.X < g.X0 || p.X > g.X1 || p.Y < g.Y0 || p.Y > g.Y1)
+	return g.Rect().Contains(p)
 }
 
 func (g *Grid[T]) Set(p point.Point, val T) {
-	// We sliently ignore out of bounds errors
+	// We sliently ignore out of bounds errors (unless strict)
 	if !g.CheckBounds(p) {
+		if g.strict {
+			panic(fmt.Errorf("%w: %v", ErrOutOfBounds, p))
+		}
 		return
 	}
-	g.Data[(p.X-g.X0)+(p.Y-g.Y0)*g.Width] = val
+	g.Data[g.index(p)] = val
 }
 
 func (g *Grid[T]) Get(p point.Point) (out T) {
-	// Return zero val if out of bounds
+	// Return zero val if out of bounds (unless strict)
 	if !g.CheckBounds(p) {
+		if g.strict {
+			panic(fmt.Errorf("%w: %v", ErrOutOfBounds, p))
+		}
 		return
 	}
-	return g.Data[(p.X-g.X0)+(p.Y-g.Y0)*g.Width]
+	return g.Data[g.index(p)]
 }
 
-func (g *Grid[T]) DrawLine(start, end point.Point, val T) {
-	if start.X == end.X {
-		for y := util.Min(start.Y, end.Y); y <= util.Max(start.Y, end.Y); y++ {
-			g.Set(point.Point{start.X, y}, val)
-		}
-	} else {
-		for x := util.Min(start.X, end.X); x <= util.Max(start.X, end.X); x++ {
-			g.Set(point.Point{x, start.Y}, val)
-		}
+// Get returning ErrOutOfBounds (rather than ignoring/panicking)
+func (g *Grid[T]) GetE(p point.Point) (out T, err error) {
+	if !g.CheckBounds(p) {
+		return out, fmt.Errorf("%w: %v", ErrOutOfBounds, p)
 	}
+	return g.Data[g.index(p)], nil
+}
+
+// Set returning ErrOutOfBounds (rather than ignoring/panicking)
+func (g *Grid[T]) SetE(p point.Point, val T) error {
+	if !g.CheckBounds(p) {
+		return fmt.Errorf("%w: %v", ErrOutOfBounds, p)
+	}
+	g.Data[g.index(p)] = val
+	return nil
 }
 
 func (g *Grid[T]) String() string {
@@ -73,43 +109,36 @@ func (g *Grid[T]) String() string {
 	for y := 0; y < g.Height; y++ {
 		line := make([]string, g.Width)
 		for x := 0; x < g.Width; x++ {
-			line[x] = fmt.Sprintf("%v", g.Data[(y*g.Width)+x])
+			line[x] = fmt.Sprintf("%v", g.Get(point.Point{g.X0 + x, g.Y0 + y}))
 		}
 		rows[y] = strings.Join(line, "")
 	}
 	return strings.Join(rows, "\n")
 }
 
+// Adjacent points (bounded unless a topology has been set)
 func (g *Grid[T]) Adjacent(p point.Point) (out []point.Point) {
-	for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
-		p1 := p.Move(v.dx, v.dy)
-		if g.CheckBounds(p1) {
-			out = append(out, p1)
+	for _, p1 := range p.Adjacent() {
+		if p2, ok := g.mapPoint(p, p1, Bounded); ok {
+			out = append(out, p2)
 		}
 	}
 	return
 }
 
+// Adjacent points (wrapping unless a topology has been set)
 func (g *Grid[T]) AdjacentWrap(p point.Point) (out []point.Point) {
-	for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
-		out = append(out, g.Move(p, v.dx, v.dy))
+	for _, p1 := range p.Adjacent() {
+		if p2, ok := g.mapPoint(p, p1, Torus); ok {
+			out = append(out, p2)
+		}
 	}
 	return
 }
 
+// Move point by dx,dy (wrapping unless a topology has been set). If the
+// topology doesn't allow the move the (out of bounds) point is returned.
 func (g *Grid[T]) Move(p point.Point, dx, dy int) point.Point {
-	p1 := p.Move(dx, dy)
-	if !g.CheckBounds(p1) {
-		if p1.X > g.X1 {
-			p1.X = g.X0 + (p1.X-g.X1-1)%g.Width
-		} else if p1.X < g.X0 {
-			p1.X = g.X1 - (g.X0-p1.X-1)%g.Width
-		}
-		if p1.Y > g.Y1 {
-			p1.Y = g.Y0 + (p1.Y-g.Y1-1)%g.Width
-		} else if p1.Y < g.Y0 {
-			p1.Y = g.Y1 - (g.Y0-p1.Y-1)%g.Width
-		}
-	}
+	p1, _ := g.mapPoint(p, p.Move(dx, dy), Torus)
 	return p1
 }
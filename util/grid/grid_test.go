@@ -14,6 +14,22 @@ func (d _gridData) String() string {
 	return fmt.Sprintf("%3d", d)
 }
 
+// Create a grid for tests (data, if given, must fill the grid)
+func testGrid[T any](t *testing.T, x0, y0, x1, y1 int, data ...T) *Grid[T] {
+	t.Helper()
+	g, err := NewGrid[T](x0, y0, x1, y1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > 0 {
+		if len(data) != len(g.Data) {
+			t.Fatalf("Expected %d values: %d", len(g.Data), len(data))
+		}
+		copy(g.Data, data)
+	}
+	return g
+}
+
 func TestGridSetGetPrint(t *testing.T) {
 	for _, v := range []struct{ x0, y0, x1, y1 int }{{0, 0, 5, 5}, {-2, -2, 2, 2}} {
 		g, err := NewGrid[point.Point](v.x0, v.y0, v.x1, v.y1)
@@ -45,6 +61,17 @@ func TestGridInvalid(t *testing.T) {
 	}
 }
 
+func TestGridFromRect(t *testing.T) {
+	r := point.FromPoints(point.Point{-3, 2}, point.Point{4, 5})
+	g, err := NewGridFromRect[int](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Rect() != r || g.Width != 8 || g.Height != 4 || len(g.Data) != r.Area() {
+		t.Error(g.Rect())
+	}
+}
+
 func TestGridCheckBounds(t *testing.T) {
 
 	g, err := NewGrid[int](0, 0, 10, 10)
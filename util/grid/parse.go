@@ -0,0 +1,64 @@
+package grid
+
+import (
+	"errors"
+	"io"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// Create grid with origin 0,0 from (rectangular) array
+func NewGridFromArray[T any](a array.Array[T]) (*Grid[T], error) {
+	if len(a) == 0 || len(a[0]) == 0 {
+		return nil, errors.New("Empty array")
+	}
+	w, h := len(a[0]), len(a)
+	g := &Grid[T]{X0: 0, Y0: 0, X1: w - 1, Y1: h - 1, Width: w, Height: h}
+	g.Data = make([]T, 0, w*h)
+	for _, row := range a {
+		if len(row) != w {
+			return nil, errors.New("Array not rectangular")
+		}
+		g.Data = append(g.Data, row...)
+	}
+	return g, nil
+}
+
+// Parse ASCII map into grid using symbols to map each rune (see
+// array.ParseArrayMarkers)
+func ParseGrid[T any](r io.Reader, symbols map[rune]T) (*Grid[T], error) {
+	g, _, err := ParseGridMarkers(r, symbols, "")
+	return g, err
+}
+
+// Parse ASCII map into grid and return the positions of any runes in markers
+func ParseGridMarkers[T any](r io.Reader, symbols map[rune]T, markers string) (*Grid[T], map[rune][]point.Point, error) {
+	a, found, err := array.ParseArrayMarkers(r, symbols, markers)
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := NewGridFromArray(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, found, nil
+}
+
+// Format grid as ASCII map (see array.Format) - markers use grid coordinates
+func Format[T comparable](g *Grid[T], symbols map[rune]T, markers map[rune][]point.Point) string {
+	a := make(array.Array[T], g.Height)
+	for y := 0; y < g.Height; y++ {
+		a[y] = make([]T, g.Width)
+		for x := 0; x < g.Width; x++ {
+			a[y][x] = g.Get(point.Point{g.X0 + x, g.Y0 + y})
+		}
+	}
+	offset := make(map[rune][]point.Point)
+	for c, pp := range markers {
+		for _, p := range pp {
+			offset[c] = append(offset[c], p.Move(-g.X0, -g.Y0))
+		}
+	}
+	return array.Format(a, symbols, offset)
+}
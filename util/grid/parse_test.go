@@ -0,0 +1,54 @@
+package grid
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/point"
+	"golang.org/x/exp/slices"
+)
+
+func TestParseGrid(t *testing.T) {
+	symbols := map[rune]int{'.': 0, '#': 1, 'S': 0}
+	g, markers, err := ParseGridMarkers(bytes.NewBufferString("..#\n#S\n"), symbols, "S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Width != 3 || g.Height != 2 || !slices.Equal(g.Data, []int{0, 0, 1, 1, 0, 0}) {
+		t.Error(g)
+	}
+	if !slices.Equal(markers['S'], []point.Point{{1, 1}}) {
+		t.Error(markers)
+	}
+	if s := Format(g, symbols, markers); s != "..#\n#S." {
+		t.Errorf("%q", s)
+	}
+	// Markers use grid coordinates
+	sub, err := g.SubGrid(1, 0, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := Format(sub, symbols, markers); s != ".#\nS." {
+		t.Errorf("%q", s)
+	}
+	if _, err := ParseGrid(bytes.NewBufferString(""), symbols); err == nil {
+		t.Error("Expected error")
+	}
+	if _, err := ParseGrid(bytes.NewBufferString("..x"), symbols); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestNewGridFromArray(t *testing.T) {
+	g, err := NewGridFromArray(array.Array[int]{{1, 2, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Get(point.Point{2, 0}) != 3 || g.X1 != 2 || g.Y1 != 0 {
+		t.Error(g)
+	}
+	if _, err := NewGridFromArray(array.Array[int]{{1, 2}, {3}}); err == nil {
+		t.Error("Expected error")
+	}
+}
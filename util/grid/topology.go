@@ -0,0 +1,80 @@
+package grid
+
+import (
+	"errors"
+
+	"github.com/paulc/aoc2022/util/point"
+)
+
+var ErrOutOfBounds = errors.New("Out of bounds")
+
+// Topology maps point p (reached by moving from point from) back onto a grid
+// with bounds x0,y0 -> x1,y1. Returns false if p can't be reached.
+type Topology func(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool)
+
+// Edges are hard boundaries
+func Bounded(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool) {
+	return p, !(p.X < x0 || p.X > x1 || p.Y < y0 || p.Y > y1)
+}
+
+// Wrap both axes
+func Torus(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool) {
+	return point.Point{wrap(p.X, x0, x1), wrap(p.Y, y0, y1)}, true
+}
+
+// Wrap left/right edges only
+func CylinderX(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool) {
+	return Bounded(x0, y0, x1, y1, from, point.Point{wrap(p.X, x0, x1), p.Y})
+}
+
+// Wrap top/bottom edges only
+func CylinderY(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool) {
+	return Bounded(x0, y0, x1, y1, from, point.Point{p.X, wrap(p.Y, y0, y1)})
+}
+
+// Custom topology - f is only called for moves which leave the grid and
+// should return the destination point (eg. for cube folding)
+func EdgeMap(f func(from, p point.Point) (point.Point, bool)) Topology {
+	return func(x0, y0, x1, y1 int, from, p point.Point) (point.Point, bool) {
+		if p1, ok := Bounded(x0, y0, x1, y1, from, p); ok {
+			return p1, true
+		}
+		p1, ok := f(from, p)
+		if !ok {
+			return p, false
+		}
+		return Bounded(x0, y0, x1, y1, from, p1)
+	}
+}
+
+func wrap(v, lo, hi int) int {
+	n := hi - lo + 1
+	return lo + ((v-lo)%n+n)%n
+}
+
+// Set topology used by Move/Adjacent/AdjacentWrap (nil restores the default:
+// Adjacent is bounded and Move/AdjacentWrap wrap as a torus)
+func (g *Grid[T]) SetTopology(t Topology) *Grid[T] {
+	g.topology = t
+	return g
+}
+
+// In strict mode Get/Set panic with ErrOutOfBounds rather than silently
+// ignoring out of bounds access (GetE/SetE always return ErrOutOfBounds)
+func (g *Grid[T]) SetStrict(strict bool) *Grid[T] {
+	g.strict = strict
+	return g
+}
+
+func (g *Grid[T]) mapPoint(from, p point.Point, t Topology) (point.Point, bool) {
+	if g.topology != nil {
+		t = g.topology
+	}
+	return t(g.X0, g.Y0, g.X1, g.Y1, from, p)
+}
+
+// Map p (reached by moving from point from) onto the grid using the grid
+// topology (bounded by default)
+func (g *Grid[T]) Map(from, p point.Point) (point.Point, bool) {
+	return g.mapPoint(from, p, Bounded)
+}
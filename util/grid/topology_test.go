@@ -0,0 +1,123 @@
+package grid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"golang.org/x/exp/slices"
+)
+
+func TestGridMoveNonSquare(t *testing.T) {
+	g, err := NewGrid[int](0, 0, 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct {
+		p      point.Point
+		dx, dy int
+		p2     point.Point
+	}{
+		{point.Point{0, 0}, 0, -1, point.Point{0, 2}},
+		{point.Point{0, 2}, 0, 1, point.Point{0, 0}},
+		{point.Point{5, 2}, 1, 4, point.Point{0, 0}},
+		{point.Point{0, 0}, -7, -7, point.Point{5, 2}},
+	} {
+		if g.Move(v.p, v.dx, v.dy) != v.p2 {
+			t.Error(v, "::", g.Move(v.p, v.dx, v.dy))
+		}
+	}
+	if adj := g.AdjacentWrap(point.Point{5, 2}); !slices.Equal(adj, []point.Point{{4, 2}, {5, 1}, {0, 2}, {5, 0}}) {
+		t.Error(adj)
+	}
+}
+
+func TestGridTopology(t *testing.T) {
+	g, err := NewGrid[int](0, 0, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct {
+		t   Topology
+		adj []point.Point
+	}{
+		{Bounded, []point.Point{{1, 0}, {0, 1}}},
+		{Torus, []point.Point{{3, 0}, {0, 2}, {1, 0}, {0, 1}}},
+		{CylinderX, []point.Point{{3, 0}, {1, 0}, {0, 1}}},
+		{CylinderY, []point.Point{{0, 2}, {1, 0}, {0, 1}}},
+	} {
+		g.SetTopology(v.t)
+		if adj := g.Adjacent(point.Point{0, 0}); !slices.Equal(adj, v.adj) {
+			t.Error(adj, v.adj)
+		}
+		if adj := g.AdjacentWrap(point.Point{0, 0}); !slices.Equal(adj, v.adj) {
+			t.Error(adj, v.adj)
+		}
+	}
+	g.SetTopology(Bounded)
+	if p := g.Move(point.Point{0, 0}, -1, 0); p != (point.Point{-1, 0}) {
+		t.Error(p)
+	}
+	// Leaving the right edge moves down one row at the left edge
+	g.SetTopology(EdgeMap(func(from, p point.Point) (point.Point, bool) {
+		if p.X > 3 {
+			return point.Point{0, p.Y + 1}, true
+		}
+		return p, false
+	}))
+	if p := g.Move(point.Point{3, 0}, 1, 0); p != (point.Point{0, 1}) {
+		t.Error(p)
+	}
+	if adj := g.Adjacent(point.Point{3, 2}); !slices.Equal(adj, []point.Point{{2, 2}, {3, 1}}) {
+		t.Error(adj)
+	}
+	g.SetTopology(nil)
+	if p := g.Move(point.Point{0, 0}, -1, 0); p != (point.Point{3, 0}) {
+		t.Error(p)
+	}
+}
+
+func TestGridStrict(t *testing.T) {
+	g, err := NewGrid[int](0, 0, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Set(point.Point{10, 10}, 1)
+	if g.Get(point.Point{10, 10}) != 0 {
+		t.Error("Get")
+	}
+	g.SetStrict(true)
+	for _, f := range []func(){
+		func() { g.Set(point.Point{10, 10}, 1) },
+		func() { g.Get(point.Point{-1, 0}) },
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || !errors.Is(err, ErrOutOfBounds) {
+					t.Error("Expected ErrOutOfBounds:", r)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestGridGetSetE(t *testing.T) {
+	g, err := NewGrid[int](0, 0, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetE(point.Point{3, 2}, 5); err != nil {
+		t.Error(err)
+	}
+	if v, err := g.GetE(point.Point{3, 2}); v != 5 || err != nil {
+		t.Error(v, err)
+	}
+	if err := g.SetE(point.Point{4, 2}, 1); !errors.Is(err, ErrOutOfBounds) {
+		t.Error(err)
+	}
+	if _, err := g.GetE(point.Point{0, -1}); !errors.Is(err, ErrOutOfBounds) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,84 @@
+package grid
+
+import (
+	"errors"
+
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// Create new grid (w x h) with the same origin as g where f maps each
+// (relative) x,y in the new grid to the source x,y in g
+func (g *Grid[T]) transform(w, h int, f func(x, y int) (int, int)) *Grid[T] {
+	out := &Grid[T]{X0: g.X0, Y0: g.Y0, X1: g.X0 + w - 1, Y1: g.Y0 + h - 1, Width: w, Height: h}
+	out.Data = make([]T, w*h)
+	out.topology, out.strict = g.topology, g.strict
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			x1, y1 := f(x, y)
+			out.Data[x+y*w] = g.Get(point.Point{g.X0 + x1, g.Y0 + y1})
+		}
+	}
+	return out
+}
+
+// Rotate clockwise
+func (g *Grid[T]) Rotate90() *Grid[T] {
+	return g.transform(g.Height, g.Width, func(x, y int) (int, int) { return y, g.Height - 1 - x })
+}
+
+func (g *Grid[T]) Rotate180() *Grid[T] {
+	return g.transform(g.Width, g.Height, func(x, y int) (int, int) { return g.Width - 1 - x, g.Height - 1 - y })
+}
+
+func (g *Grid[T]) Rotate270() *Grid[T] {
+	return g.transform(g.Height, g.Width, func(x, y int) (int, int) { return g.Width - 1 - y, x })
+}
+
+// Mirror left/right
+func (g *Grid[T]) FlipH() *Grid[T] {
+	return g.transform(g.Width, g.Height, func(x, y int) (int, int) { return g.Width - 1 - x, y })
+}
+
+// Mirror top/bottom
+func (g *Grid[T]) FlipV() *Grid[T] {
+	return g.transform(g.Width, g.Height, func(x, y int) (int, int) { return x, g.Height - 1 - y })
+}
+
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(g.Height, g.Width, func(x, y int) (int, int) { return y, x })
+}
+
+// All 8 rotations/reflections of g (the first is an unchanged copy)
+func (g *Grid[T]) Symmetries() []*Grid[T] {
+	return []*Grid[T]{
+		g.transform(g.Width, g.Height, func(x, y int) (int, int) { return x, y }),
+		g.Rotate90(),
+		g.Rotate180(),
+		g.Rotate270(),
+		g.FlipH(),
+		g.FlipV(),
+		g.Transpose(),
+		g.transform(g.Height, g.Width, func(x, y int) (int, int) { return g.Width - 1 - y, g.Height - 1 - x }),
+	}
+}
+
+// Zero-copy view of the region x0,y0 -> x1,y1 (inclusive) using the same
+// coordinates as g. Writes through the view outside the region are ignored.
+func (g *Grid[T]) SubGrid(x0, y0, x1, y1 int) (*Grid[T], error) {
+	if x1 < x0 || y1 < y0 || !g.CheckBounds(point.Point{x0, y0}) || !g.CheckBounds(point.Point{x1, y1}) {
+		return nil, errors.New("Invalid bounds")
+	}
+	stride := g.stride
+	if stride == 0 {
+		stride = g.Width
+	}
+	start, end := g.index(point.Point{x0, y0}), g.index(point.Point{x1, y1})+1
+	return &Grid[T]{
+		X0: x0, Y0: y0, X1: x1, Y1: y1,
+		Width: x1 - x0 + 1, Height: y1 - y0 + 1,
+		Data:     g.Data[start:end:end],
+		stride:   stride,
+		topology: g.topology,
+		strict:   g.strict,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"golang.org/x/exp/slices"
+)
+
+// 0 1 2
+// 3 4 5
+func TestGridTransform(t *testing.T) {
+	g := testGrid(t, -1, -1, 1, 0, 0, 1, 2, 3, 4, 5)
+	for _, v := range []struct {
+		g    *Grid[int]
+		w, h int
+		data []int
+	}{
+		{g.Rotate90(), 2, 3, []int{3, 0, 4, 1, 5, 2}},
+		{g.Rotate180(), 3, 2, []int{5, 4, 3, 2, 1, 0}},
+		{g.Rotate270(), 2, 3, []int{2, 5, 1, 4, 0, 3}},
+		{g.FlipH(), 3, 2, []int{2, 1, 0, 5, 4, 3}},
+		{g.FlipV(), 3, 2, []int{3, 4, 5, 0, 1, 2}},
+		{g.Transpose(), 2, 3, []int{0, 3, 1, 4, 2, 5}},
+		{g.Rotate90().Rotate90().Rotate90().Rotate90(), 3, 2, []int{0, 1, 2, 3, 4, 5}},
+	} {
+		if v.g.Width != v.w || v.g.Height != v.h || !slices.Equal(v.g.Data, v.data) {
+			t.Error(v.data, v.g.Data)
+		}
+		if v.g.X0 != g.X0 || v.g.Y0 != g.Y0 || v.g.X1 != g.X0+v.w-1 || v.g.Y1 != g.Y0+v.h-1 {
+			t.Error(v.g)
+		}
+	}
+}
+
+func TestGridSymmetries(t *testing.T) {
+	g := testGrid(t, -1, -1, 1, 0, 0, 1, 2, 3, 4, 5)
+	s := g.Symmetries()
+	if len(s) != 8 {
+		t.Fatal(s)
+	}
+	for i := 0; i < len(s); i++ {
+		for j := i + 1; j < len(s); j++ {
+			if s[i].Width == s[j].Width && slices.Equal(s[i].Data, s[j].Data) {
+				t.Error(i, j, s[i].Data)
+			}
+		}
+	}
+	if !slices.Equal(s[7].Data, []int{5, 2, 4, 1, 3, 0}) {
+		t.Error(s[7].Data)
+	}
+}
+
+func TestGridSubGrid(t *testing.T) {
+	g, err := NewGrid[int](0, 0, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range g.Data {
+		g.Data[i] = i
+	}
+	sub, err := g.SubGrid(1, 1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Width != 2 || sub.Height != 3 || sub.Get(point.Point{1, 1}) != 5 || sub.Get(point.Point{2, 3}) != 14 {
+		t.Error(sub)
+	}
+	if sub.String() != "56\n910\n1314" {
+		t.Error(sub.String())
+	}
+	// Writes go through to g but are bounded by the view
+	sub.Set(point.Point{2, 2}, 99)
+	sub.Set(point.Point{3, 2}, 99)
+	sub.Set(point.Point{0, 0}, 99)
+	if g.Get(point.Point{2, 2}) != 99 || g.Get(point.Point{3, 2}) != 11 || g.Get(point.Point{0, 0}) != 0 {
+		t.Error(g)
+	}
+	c, err := sub.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(c.Data, []int{5, 6, 9, 99, 13, 14}) {
+		t.Error(c.Data)
+	}
+	if r := sub.Rotate90(); !slices.Equal(r.Data, []int{13, 9, 5, 14, 99, 6}) {
+		t.Error(r.Data)
+	}
+	sub2, err := sub.SubGrid(2, 2, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub2.Get(point.Point{2, 3}) != 14 || sub2.Get(point.Point{1, 3}) != 0 {
+		t.Error(sub2)
+	}
+	// Single row/column/cell views can be copied
+	for _, v := range []struct{ x0, y0, x1, y1 int }{{2, 2, 2, 3}, {0, 1, 3, 1}, {3, 3, 3, 3}} {
+		sub, err := g.SubGrid(v.x0, v.y0, v.x1, v.y1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := sub.Copy()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.String() != sub.String() || len(c.Data) != sub.Width*sub.Height {
+			t.Error(v, c, sub)
+		}
+	}
+	for _, v := range []struct{ x0, y0, x1, y1 int }{{-1, 0, 2, 2}, {0, 0, 4, 2}, {2, 2, 1, 1}} {
+		if _, err := g.SubGrid(v.x0, v.y0, v.x1, v.y1); err == nil {
+			t.Error("Expected error", v)
+		}
+	}
+}
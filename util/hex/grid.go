@@ -0,0 +1,106 @@
+package hex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/paulc/aoc2022/util/path"
+)
+
+// Hexagonal grid of hexes within Radius of Centre. Data is stored as a
+// (2*Radius+1)^2 rhombus with the corners unused.
+type HexGrid[T any] struct {
+	Centre Hex
+	Radius int
+	Data   []T
+}
+
+func NewHexGrid[T any](centre Hex, radius int) (*HexGrid[T], error) {
+	if radius < 0 {
+		return nil, errors.New("Invalid radius")
+	}
+	n := 2*radius + 1
+	return &HexGrid[T]{Centre: centre, Radius: radius, Data: make([]T, n*n)}, nil
+}
+
+func (g *HexGrid[T]) index(h Hex) int {
+	d := h.Sub(g.Centre)
+	return (d.Q + g.Radius) + (d.R+g.Radius)*(2*g.Radius+1)
+}
+
+func (g *HexGrid[T]) CheckBounds(h Hex) bool {
+	return h.Distance(g.Centre) <= g.Radius
+}
+
+// Set value (out of bounds is ignored)
+func (g *HexGrid[T]) Set(h Hex, val T) {
+	if g.CheckBounds(h) {
+		g.Data[g.index(h)] = val
+	}
+}
+
+// Get value (out of bounds returns zero value)
+func (g *HexGrid[T]) Get(h Hex) (out T) {
+	if g.CheckBounds(h) {
+		out = g.Data[g.index(h)]
+	}
+	return
+}
+
+// Adjacent hexes within grid
+func (g *HexGrid[T]) Adjacent(h Hex) (out []Hex) {
+	for _, h1 := range h.Adjacent() {
+		if g.CheckBounds(h1) {
+			out = append(out, h1)
+		}
+	}
+	return
+}
+
+// Call f for each hex (row by row)
+func (g *HexGrid[T]) Each(f func(Hex, T)) {
+	for r := -g.Radius; r <= g.Radius; r++ {
+		for q := -g.Radius; q <= g.Radius; q++ {
+			if h := g.Centre.Add(Hex{q, r}); g.CheckBounds(h) {
+				f(h, g.Get(h))
+			}
+		}
+	}
+}
+
+// Build graph for path.Astar - cost returns the cost of moving between
+// adjacent hexes (false if the move isn't possible)
+func (g *HexGrid[T]) Graph(cost func(from, to Hex) (float64, bool)) path.Graph[Hex] {
+	out := make(path.Graph[Hex])
+	g.Each(func(h Hex, _ T) {
+		edges := []path.Edge[Hex]{}
+		for _, h1 := range g.Adjacent(h) {
+			if c, ok := cost(h, h1); ok {
+				edges = append(edges, path.Edge[Hex]{To: h1, Cost: c})
+			}
+		}
+		out[h] = edges
+	})
+	return out
+}
+
+// A* heuristic (hex distance to end)
+func Heuristic(end Hex) func(Hex) float64 {
+	return func(h Hex) float64 { return float64(h.Distance(end)) }
+}
+
+// Rows offset by half a cell to show the hex layout
+func (g *HexGrid[T]) String() string {
+	rows := []string{}
+	for r := -g.Radius; r <= g.Radius; r++ {
+		line := []string{}
+		for q := -g.Radius; q <= g.Radius; q++ {
+			if h := g.Centre.Add(Hex{q, r}); g.CheckBounds(h) {
+				line = append(line, fmt.Sprintf("%v", g.Get(h)))
+			}
+		}
+		rows = append(rows, strings.Repeat(" ", abs(r))+strings.Join(line, " "))
+	}
+	return strings.Join(rows, "\n")
+}
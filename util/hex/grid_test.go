@@ -0,0 +1,52 @@
+package hex
+
+import (
+	"testing"
+)
+
+func TestHexGrid(t *testing.T) {
+	g, err := NewHexGrid[int](Hex{1, 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	g.Each(func(h Hex, _ int) { count++; g.Set(h, h.Distance(g.Centre)) })
+	if count != 19 {
+		t.Error(count)
+	}
+	if g.Get(Hex{3, 0}) != 2 || g.Get(Hex{4, 0}) != 0 || g.CheckBounds(Hex{3, 3}) {
+		t.Error(g)
+	}
+	if len(g.Adjacent(g.Centre)) != 6 || len(g.Adjacent(Hex{3, -1})) != 3 {
+		t.Error(g.Adjacent(Hex{3, -1}))
+	}
+	expected := "  2 2 2\n 2 1 1 2\n2 1 0 1 2\n 2 1 1 2\n  2 2 2"
+	if g.String() != expected {
+		t.Errorf("\n%s", g)
+	}
+	if _, err := NewHexGrid[int](Hex{}, -1); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestHexGridAstar(t *testing.T) {
+	g, _ := NewHexGrid[bool](Hex{}, 3)
+	// Wall across the middle with a gap at the west end
+	for q := -2; q <= 3; q++ {
+		g.Set(Hex{q, 0}, true)
+	}
+	graph := g.Graph(func(from, to Hex) (float64, bool) { return 1, !g.Get(to) })
+	start, end := Hex{1, -2}, Hex{0, 2}
+	cost, path := graph.Astar(start, end, Heuristic(end))
+	if path[0] != end || path[len(path)-1] != start || int(cost) != len(path)-1 {
+		t.Error(cost, path)
+	}
+	for _, h := range path {
+		if g.Get(h) {
+			t.Error("Path through wall", h)
+		}
+	}
+	if cost <= float64(start.Distance(end)) {
+		t.Error("Expected detour", cost)
+	}
+}
@@ -0,0 +1,125 @@
+package hex
+
+import (
+	"fmt"
+)
+
+// Hex in axial coordinates (pointy-top, R increases downwards). The implicit
+// third cube coordinate is S = -Q-R.
+type Hex struct {
+	Q, R int
+}
+
+// Neighbour directions clockwise from east
+var (
+	E  = Hex{1, 0}
+	SE = Hex{0, 1}
+	SW = Hex{-1, 1}
+	W  = Hex{-1, 0}
+	NW = Hex{0, -1}
+	NE = Hex{1, -1}
+)
+
+var Dirs = []Hex{E, SE, SW, W, NW, NE}
+
+// From cube coordinates (q+r+s must be 0)
+func FromCube(q, r, s int) (Hex, error) {
+	if q+r+s != 0 {
+		return Hex{}, fmt.Errorf("Invalid cube coordinates: %d,%d,%d", q, r, s)
+	}
+	return Hex{q, r}, nil
+}
+
+func (h Hex) S() int {
+	return -h.Q - h.R
+}
+
+func (h Hex) Cube() (q, r, s int) {
+	return h.Q, h.R, h.S()
+}
+
+func (h Hex) String() string {
+	return fmt.Sprintf("<%d,%d,%d>", h.Q, h.R, h.S())
+}
+
+func (h Hex) Add(h2 Hex) Hex {
+	return Hex{h.Q + h2.Q, h.R + h2.R}
+}
+
+func (h Hex) Sub(h2 Hex) Hex {
+	return Hex{h.Q - h2.Q, h.R - h2.R}
+}
+
+func (h Hex) Scale(n int) Hex {
+	return Hex{h.Q * n, h.R * n}
+}
+
+// Move n steps in direction d
+func (h Hex) Step(d Hex, n int) Hex {
+	return h.Add(d.Scale(n))
+}
+
+func (h Hex) Adjacent() (out []Hex) {
+	for _, d := range Dirs {
+		out = append(out, h.Add(d))
+	}
+	return
+}
+
+// Number of steps between h and h2
+func (h Hex) Distance(h2 Hex) int {
+	d := h.Sub(h2)
+	return max(abs(d.Q), abs(d.R), abs(d.S()))
+}
+
+// Rotate 60° clockwise around centre
+func (h Hex) RotateRight(centre Hex) Hex {
+	d := h.Sub(centre)
+	return centre.Add(Hex{-d.R, -d.S()})
+}
+
+// Rotate 60° anticlockwise around centre
+func (h Hex) RotateLeft(centre Hex) Hex {
+	d := h.Sub(centre)
+	return centre.Add(Hex{-d.S(), -d.Q})
+}
+
+// Hexes at exactly radius from h (clockwise from the north-west corner)
+func (h Hex) Ring(radius int) []Hex {
+	if radius == 0 {
+		return []Hex{h}
+	}
+	out := make([]Hex, 0, 6*radius)
+	p := h.Step(NW, radius)
+	for _, d := range Dirs {
+		for i := 0; i < radius; i++ {
+			out = append(out, p)
+			p = p.Add(d)
+		}
+	}
+	return out
+}
+
+// Hexes within radius of h, ordered by ring
+func (h Hex) Spiral(radius int) (out []Hex) {
+	for i := 0; i <= radius; i++ {
+		out = append(out, h.Ring(i)...)
+	}
+	return
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max(a int, rest ...int) int {
+	for _, v := range rest {
+		if v > a {
+			a = v
+		}
+	}
+	return a
+}
@@ -0,0 +1,76 @@
+package hex
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestHex(t *testing.T) {
+	h := Hex{2, -1}
+	if q, r, s := h.Cube(); q+r+s != 0 || s != -1 {
+		t.Error(q, r, s)
+	}
+	if _, err := FromCube(1, 1, 1); err == nil {
+		t.Error("Expected error")
+	}
+	for _, d := range Dirs {
+		if h.Add(d).Distance(h) != 1 {
+			t.Error(d)
+		}
+	}
+	if d := (Hex{0, 0}).Distance(Hex{3, -5}); d != 5 {
+		t.Error(d)
+	}
+	if d := h.Step(SW, 4).Distance(h); d != 4 {
+		t.Error(d)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for i, d := range Dirs {
+		if r := d.RotateRight(Hex{}); r != Dirs[(i+1)%6] {
+			t.Error("RotateRight", d, r)
+		}
+		if l := d.RotateLeft(Hex{}); l != Dirs[(i+5)%6] {
+			t.Error("RotateLeft", d, l)
+		}
+	}
+	c, h := Hex{1, 1}, Hex{3, -1}
+	p := h
+	for i := 0; i < 6; i++ {
+		p = p.RotateRight(c)
+		if p.Distance(c) != h.Distance(c) {
+			t.Error(p)
+		}
+	}
+	if p != h {
+		t.Error(p)
+	}
+}
+
+func TestRingSpiral(t *testing.T) {
+	c := Hex{-2, 3}
+	for r := 0; r < 5; r++ {
+		ring := c.Ring(r)
+		if r > 0 && len(ring) != 6*r {
+			t.Error(r, len(ring))
+		}
+		for i, h := range ring {
+			if h.Distance(c) != r {
+				t.Error(r, h)
+			}
+			if r > 0 && h.Distance(ring[(i+1)%len(ring)]) != 1 {
+				t.Error("Not contiguous", h)
+			}
+		}
+	}
+	s := c.Spiral(3)
+	if len(s) != 37 || s[0] != c {
+		t.Error(len(s))
+	}
+	slices.SortFunc(s, func(a, b Hex) bool { return a.Q < b.Q || (a.Q == b.Q && a.R < b.R) })
+	if len(slices.Compact(s)) != 37 {
+		t.Error("Duplicates")
+	}
+}
@@ -0,0 +1,190 @@
+package point
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+// Manhattan diamond - all points within Radius of Centre
+//
+// Rotating by 45° (u = x+y, v = x-y) turns a diamond into an axis aligned
+// square so most of the geometry is done in rotated coordinates. Only points
+// where u and v have the same parity map back onto the integer grid.
+type Diamond struct {
+	Centre Point
+	Radius int
+}
+
+func NewDiamond(centre Point, radius int) Diamond {
+	return Diamond{centre, radius}
+}
+
+func (d Diamond) String() string {
+	return fmt.Sprintf("<%v r=%d>", d.Centre, d.Radius)
+}
+
+func Rotate45(p Point) Point {
+	return Point{p.X + p.Y, p.X - p.Y}
+}
+
+// Inverse of Rotate45 (false if p doesn't map to an integer point)
+func Unrotate45(p Point) (Point, bool) {
+	if (p.X-p.Y)%2 != 0 {
+		return Point{}, false
+	}
+	return Point{(p.X + p.Y) / 2, (p.X - p.Y) / 2}, true
+}
+
+// Bounding square in rotated coordinates
+func (d Diamond) Rotated() Rect {
+	c := Rotate45(d.Centre)
+	return NewRect(c.X-d.Radius, c.Y-d.Radius, c.X+d.Radius, c.Y+d.Radius)
+}
+
+// Bounding box in normal coordinates
+func (d Diamond) Bounds() Rect {
+	return NewRect(d.Centre.X-d.Radius, d.Centre.Y-d.Radius, d.Centre.X+d.Radius, d.Centre.Y+d.Radius)
+}
+
+func (d Diamond) Contains(p Point) bool {
+	return d.Centre.Distance(p) <= d.Radius
+}
+
+func (d Diamond) Expand(n int) Diamond {
+	return Diamond{d.Centre, d.Radius + n}
+}
+
+// Number of points covered
+func (d Diamond) Area() int {
+	if d.Radius < 0 {
+		return 0
+	}
+	return 2*d.Radius*(d.Radius+1) + 1
+}
+
+// Span covered on row y
+func (d Diamond) Row(y int) (x0, x1 int, ok bool) {
+	dx := d.Radius - d.Centre.Ydistance(Point{d.Centre.X, y})
+	return d.Centre.X - dx, d.Centre.X + dx, dx >= 0
+}
+
+// Points at exactly Radius from Centre (clockwise from the top)
+func (d Diamond) Boundary() (out []Point) {
+	if d.Radius == 0 {
+		return []Point{d.Centre}
+	}
+	p := d.Centre.Move(0, -d.Radius)
+	for _, delta := range []Point{{1, 1}, {-1, 1}, {-1, -1}, {1, -1}} {
+		for i := 0; i < d.Radius; i++ {
+			out = append(out, p)
+			p = p.Add(delta)
+		}
+	}
+	return
+}
+
+// Points where the boundaries of d and d2 cross
+func (d Diamond) Intersect(d2 Diamond) (out []Point) {
+	r1, r2 := d.Rotated(), d2.Rotated()
+	for _, u := range []int{r1.Min.X, r1.Max.X, r2.Min.X, r2.Max.X} {
+		for _, v := range []int{r1.Min.Y, r1.Max.Y, r2.Min.Y, r2.Max.Y} {
+			if p, ok := Unrotate45(Point{u, v}); ok && d.Centre.Distance(p) == d.Radius && d2.Centre.Distance(p) == d2.Radius && !slices.Contains(out, p) {
+				out = append(out, p)
+			}
+		}
+	}
+	return
+}
+
+// Uncovered points in bounds from a set of candidates - the crossings of the
+// lines just outside each diamond (and the bounds edges). This is not a full
+// search: it only finds uncovered regions which are isolated points or touch
+// a corner of bounds (O(n²) candidates rather than scanning the whole area).
+func UncoveredCandidates(ds []Diamond, bounds Rect) (out []Point) {
+	us, vs := []int{}, []int{}
+	for _, d := range ds {
+		r := d.Expand(1).Rotated()
+		us, vs = append(us, r.Min.X, r.Max.X), append(vs, r.Min.Y, r.Max.Y)
+	}
+	candidates := []Point{bounds.Min, bounds.Max, {bounds.Min.X, bounds.Max.Y}, {bounds.Max.X, bounds.Min.Y}}
+	for _, u := range us {
+		for _, v := range vs {
+			if p, ok := Unrotate45(Point{u, v}); ok {
+				candidates = append(candidates, p)
+			}
+		}
+		// Crossings with the bounds edges
+		for _, x := range []int{bounds.Min.X, bounds.Max.X} {
+			candidates = append(candidates, Point{x, u - x})
+		}
+		for _, y := range []int{bounds.Min.Y, bounds.Max.Y} {
+			candidates = append(candidates, Point{u - y, y})
+		}
+	}
+	for _, v := range vs {
+		for _, x := range []int{bounds.Min.X, bounds.Max.X} {
+			candidates = append(candidates, Point{x, x - v})
+		}
+		for _, y := range []int{bounds.Min.Y, bounds.Max.Y} {
+			candidates = append(candidates, Point{v + y, y})
+		}
+	}
+outer:
+	for _, p := range candidates {
+		if !bounds.Contains(p) || slices.Contains(out, p) {
+			continue
+		}
+		for _, d := range ds {
+			if d.Contains(p) {
+				continue outer
+			}
+		}
+		out = append(out, p)
+	}
+	return
+}
+
+// Number of points covered by at least one diamond (coordinate compression
+// of the rotated squares)
+func UnionArea(ds []Diamond) (result int) {
+	squares := []Rect{}
+	us, vs := []int{}, []int{}
+	for _, d := range ds {
+		if d.Radius < 0 {
+			continue
+		}
+		r := d.Rotated()
+		squares = append(squares, r)
+		us, vs = append(us, r.Min.X, r.Max.X+1), append(vs, r.Min.Y, r.Max.Y+1)
+	}
+	slices.Sort(us)
+	slices.Sort(vs)
+	us, vs = slices.Compact(us), slices.Compact(vs)
+	for i := 0; i < len(us)-1; i++ {
+		for j := 0; j < len(vs)-1; j++ {
+			for _, r := range squares {
+				if r.Contains(Point{us[i], vs[j]}) {
+					result += latticePoints(us[i], us[i+1], vs[j], vs[j+1])
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// Number of points in [u0,u1) x [v0,v1) where u and v have the same parity
+func latticePoints(u0, u1, v0, v1 int) int {
+	even := func(a, b int) int { return floorDiv(b+1, 2) - floorDiv(a+1, 2) }
+	eu, ev := even(u0, u1), even(v0, v1)
+	ou, ov := u1-u0-eu, v1-v0-ev
+	return eu*ev + ou*ov
+}
+
+func floorDiv(a, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
@@ -0,0 +1,93 @@
+package point
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestDiamond(t *testing.T) {
+	d := NewDiamond(Point{2, -1}, 2)
+	if d.Area() != 13 || len(d.Boundary()) != 8 {
+		t.Error(d.Area(), d.Boundary())
+	}
+	count := 0
+	d.Bounds().Expand(1).Each(func(p Point) {
+		if d.Contains(p) {
+			count++
+			if !d.Rotated().Contains(Rotate45(p)) {
+				t.Error("Rotated", p)
+			}
+		}
+	})
+	if count != d.Area() {
+		t.Error(count)
+	}
+	for _, p := range d.Boundary() {
+		if d.Centre.Distance(p) != d.Radius {
+			t.Error("Boundary", p)
+		}
+	}
+	if x0, x1, ok := d.Row(0); !ok || x0 != 1 || x1 != 3 {
+		t.Error(x0, x1, ok)
+	}
+	if _, _, ok := d.Row(2); ok {
+		t.Error("Row")
+	}
+	if p, ok := Unrotate45(Rotate45(Point{-3, 7})); !ok || p != (Point{-3, 7}) {
+		t.Error(p)
+	}
+	if _, ok := Unrotate45(Point{1, 0}); ok {
+		t.Error("Unrotate45")
+	}
+}
+
+func TestDiamondIntersect(t *testing.T) {
+	d1, d2 := NewDiamond(Point{0, 0}, 2), NewDiamond(Point{2, 0}, 2)
+	pp := d1.Intersect(d2)
+	slices.SortFunc(pp, func(a, b Point) bool { return a.Y < b.Y })
+	if !slices.Equal(pp, []Point{{1, -1}, {1, 1}}) {
+		t.Error(pp)
+	}
+	if pp := d1.Intersect(NewDiamond(Point{10, 0}, 1)); len(pp) != 0 {
+		t.Error(pp)
+	}
+}
+
+func TestUncoveredCandidates(t *testing.T) {
+	// Four diamonds leaving a single hole at 5,5
+	ds := []Diamond{
+		NewDiamond(Point{2, 2}, 5), NewDiamond(Point{8, 2}, 5),
+		NewDiamond(Point{2, 8}, 5), NewDiamond(Point{8, 8}, 5),
+	}
+	bounds := NewRect(0, 0, 10, 10)
+	if pp := UncoveredCandidates(ds, bounds); !slices.Equal(pp, []Point{{5, 5}}) {
+		t.Error(pp)
+	}
+	// Hole in the corner of bounds
+	if pp := UncoveredCandidates(ds[:3], NewRect(0, 0, 10, 10)); !slices.Contains(pp, Point{10, 10}) {
+		t.Error(pp)
+	}
+}
+
+func TestUnionArea(t *testing.T) {
+	ds := []Diamond{
+		NewDiamond(Point{0, 0}, 3), NewDiamond(Point{2, 1}, 2),
+		NewDiamond(Point{-4, 5}, 4), NewDiamond(Point{9, 9}, 0),
+	}
+	count := 0
+	NewRect(-10, -10, 12, 12).Each(func(p Point) {
+		for _, d := range ds {
+			if d.Contains(p) {
+				count++
+				return
+			}
+		}
+	})
+	if a := UnionArea(ds); a != count {
+		t.Error(a, count)
+	}
+	if a := UnionArea(ds[:1]); a != ds[0].Area() {
+		t.Error(a)
+	}
+}
@@ -0,0 +1,116 @@
+package point
+
+import "fmt"
+
+// Compass direction (clockwise in 45° steps). Y increases downwards so N is
+// {0,-1}
+type Dir int
+
+const (
+	N Dir = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+	nDir
+)
+
+const (
+	Up    = N
+	Right = E
+	Down  = S
+	Left  = W
+)
+
+var (
+	Dirs4 = []Dir{N, E, S, W}
+	Dirs8 = []Dir{N, NE, E, SE, S, SW, W, NW}
+)
+
+var dirDelta = [nDir]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+var dirNames = [nDir]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+var dirArrows = [nDir]string{"^", "↗", ">", "↘", "v", "↙", "<", "↖"}
+
+var parseDir = map[string]Dir{
+	"U": N, "R": E, "D": S, "L": W,
+	"↑": N, "→": E, "↓": S, "←": W,
+}
+
+func init() {
+	for d := N; d < nDir; d++ {
+		parseDir[dirNames[d]] = d
+		parseDir[dirArrows[d]] = d
+	}
+}
+
+// Parse direction from compass letters (N/NE/E..), U/D/L/R or arrows (^>v<)
+func ParseDir(s string) (Dir, error) {
+	if d, ok := parseDir[s]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("Invalid direction: %q", s)
+}
+
+// Implements encoding.TextUnmarshaler (see ParseDir)
+func (d *Dir) UnmarshalText(b []byte) (err error) {
+	*d, err = ParseDir(string(b))
+	return
+}
+
+func (d Dir) valid() bool {
+	return d >= 0 && d < nDir
+}
+
+func (d Dir) String() string {
+	if !d.valid() {
+		return fmt.Sprintf("Dir(%d)", int(d))
+	}
+	return dirNames[d]
+}
+
+func (d Dir) Arrow() string {
+	if !d.valid() {
+		return "?"
+	}
+	return dirArrows[d]
+}
+
+// Unit step for d (0,0 for an invalid Dir)
+func (d Dir) Delta() (dx, dy int) {
+	if !d.valid() {
+		return 0, 0
+	}
+	return dirDelta[d].X, dirDelta[d].Y
+}
+
+// Rotate n * 45° clockwise (n may be negative)
+func (d Dir) Rotate(n int) Dir {
+	return Dir(((int(d)+n)%int(nDir) + int(nDir)) % int(nDir))
+}
+
+func (d Dir) TurnRight() Dir {
+	return d.Rotate(2)
+}
+
+func (d Dir) TurnLeft() Dir {
+	return d.Rotate(-2)
+}
+
+func (d Dir) Reverse() Dir {
+	return d.Rotate(4)
+}
+
+func (d Dir) Diagonal() bool {
+	return d%2 == 1
+}
+
+// Move n steps in direction d (no move for an invalid Dir - see Delta)
+func (p Pt[T]) Step(d Dir, n T) Pt[T] {
+	dx, dy := d.Delta()
+	return Pt[T]{p.X + T(dx)*n, p.Y + T(dy)*n}
+}
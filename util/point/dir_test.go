@@ -0,0 +1,78 @@
+package point
+
+import (
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	for _, v := range []struct {
+		d, left, right, reverse Dir
+	}{
+		{N, W, E, S},
+		{E, N, S, W},
+		{SW, SE, NW, NE},
+		{W, S, N, E},
+	} {
+		if v.d.TurnLeft() != v.left || v.d.TurnRight() != v.right || v.d.Reverse() != v.reverse {
+			t.Error(v, v.d.TurnLeft(), v.d.TurnRight(), v.d.Reverse())
+		}
+	}
+	if N.Rotate(-1) != NW || NW.Rotate(1) != N || E.Rotate(17) != SE {
+		t.Error("Rotate")
+	}
+	if !NE.Diagonal() || S.Diagonal() {
+		t.Error("Diagonal")
+	}
+	if SW.String() != "SW" || E.Arrow() != ">" {
+		t.Error(SW, E.Arrow())
+	}
+	if dx, dy := Dir(8).Delta(); dx != 0 || dy != 0 || (Point{1, 2}).Step(Dir(-1), 3) != (Point{1, 2}) {
+		t.Error("Invalid Delta/Step")
+	}
+	if Dir(8).String() != "Dir(8)" || Dir(-1).String() != "Dir(-1)" || Dir(9).Arrow() != "?" {
+		t.Error(Dir(8), Dir(-1), Dir(9).Arrow())
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	for _, v := range []struct {
+		s string
+		d Dir
+	}{
+		{"N", N}, {"U", N}, {"^", N}, {"↑", N},
+		{"R", E}, {">", E}, {"→", E},
+		{"D", S}, {"v", S}, {"↓", S},
+		{"L", W}, {"<", W}, {"←", W},
+		{"NE", NE}, {"SW", SW}, {"↘", SE},
+	} {
+		if d, err := ParseDir(v.s); err != nil || d != v.d {
+			t.Error(v, d, err)
+		}
+	}
+	if _, err := ParseDir("X"); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestStep(t *testing.T) {
+	p := Point{1, 1}
+	for _, v := range []struct {
+		d Dir
+		n int
+		p Point
+	}{
+		{N, 1, Point{1, 0}},
+		{S, 3, Point{1, 4}},
+		{NW, 2, Point{-1, -1}},
+		{E, 0, Point{1, 1}},
+	} {
+		if p1 := p.Step(v.d, v.n); p1 != v.p {
+			t.Error(v, p1)
+		}
+	}
+	for _, d := range Dirs8 {
+		if dx, dy := d.Delta(); p.Step(d, 1) != p.Move(dx, dy) || p.Step(d, 1).Step(d.Reverse(), 1) != p {
+			t.Error(d)
+		}
+	}
+}
@@ -1,43 +1,118 @@
 package point
 
-type Point struct {
-	X, Y int
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Point with generic (signed integer) coordinates - eg. Pt[int64]
+type Pt[T constraints.Signed] struct {
+	X, Y T
+}
+
+type Point = Pt[int]
+
+func (p Pt[T]) Move(dx, dy T) Pt[T] {
+	return Pt[T]{p.X + dx, p.Y + dy}
+}
+
+func (p Pt[T]) Add(p2 Pt[T]) Pt[T] {
+	return Pt[T]{p.X + p2.X, p.Y + p2.Y}
+}
+
+func (p Pt[T]) Sub(p2 Pt[T]) Pt[T] {
+	return Pt[T]{p.X - p2.X, p.Y - p2.Y}
+}
+
+func (p Pt[T]) Scale(n T) Pt[T] {
+	return Pt[T]{p.X * n, p.Y * n}
+}
+
+func (p Pt[T]) Neg() Pt[T] {
+	return Pt[T]{-p.X, -p.Y}
+}
+
+// Unit step towards p (each coordinate is -1, 0 or 1)
+func (p Pt[T]) Sign() Pt[T] {
+	return Pt[T]{sign(p.X), sign(p.Y)}
 }
 
-func (p Point) Move(dx, dy int) Point {
-	return Point{p.X + dx, p.Y + dy}
+func (p Pt[T]) Dot(p2 Pt[T]) T {
+	return p.X*p2.X + p.Y*p2.Y
 }
 
-func (p Point) Distance(p2 Point) int {
-	return absint(p.X-p2.X) + absint(p.Y-p2.Y)
+// Z component of the cross product
+func (p Pt[T]) Cross(p2 Pt[T]) T {
+	return p.X*p2.Y - p.Y*p2.X
 }
 
-func (p Point) Xdistance(p2 Point) int {
-	return absint(p.X - p2.X)
+// Rotate 90° clockwise around origin (Y increases downwards)
+func (p Pt[T]) RotateRight(origin Pt[T]) Pt[T] {
+	d := p.Sub(origin)
+	return Pt[T]{origin.X - d.Y, origin.Y + d.X}
 }
 
-func (p Point) Ydistance(p2 Point) int {
-	return absint(p.Y - p2.Y)
+// Rotate 90° anticlockwise around origin
+func (p Pt[T]) RotateLeft(origin Pt[T]) Pt[T] {
+	d := p.Sub(origin)
+	return Pt[T]{origin.X + d.Y, origin.Y - d.X}
 }
 
-func (p Point) Adjacent() (out []Point) {
-	for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
-		out = append(out, Point{p.X + v.dx, p.Y + v.dy})
+// Manhattan distance
+func (p Pt[T]) Distance(p2 Pt[T]) T {
+	return abs(p.X-p2.X) + abs(p.Y-p2.Y)
+}
+
+func (p Pt[T]) Chebyshev(p2 Pt[T]) T {
+	if dx, dy := abs(p.X-p2.X), abs(p.Y-p2.Y); dx > dy {
+		return dx
+	} else {
+		return dy
+	}
+}
+
+func (p Pt[T]) Euclidean(p2 Pt[T]) float64 {
+	return math.Hypot(float64(p.X-p2.X), float64(p.Y-p2.Y))
+}
+
+func (p Pt[T]) Xdistance(p2 Pt[T]) T {
+	return abs(p.X - p2.X)
+}
+
+func (p Pt[T]) Ydistance(p2 Pt[T]) T {
+	return abs(p.Y - p2.Y)
+}
+
+func (p Pt[T]) Adjacent() (out []Pt[T]) {
+	for _, v := range []struct{ dx, dy T }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
+		out = append(out, Pt[T]{p.X + v.dx, p.Y + v.dy})
 	}
 	return
 }
 
-func (p Point) AdjacentDiagonal() (out []Point) {
-	for _, v := range []struct{ dx, dy int }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}, {-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
-		out = append(out, Point{p.X + v.dx, p.Y + v.dy})
+func (p Pt[T]) AdjacentDiagonal() (out []Pt[T]) {
+	for _, v := range []struct{ dx, dy T }{{-1, 0}, {0, -1}, {1, 0}, {0, 1}, {-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		out = append(out, Pt[T]{p.X + v.dx, p.Y + v.dy})
 	}
 	return
 }
 
-func absint(i int) int {
+func abs[T constraints.Signed](i T) T {
 	if i < 0 {
 		return -i
 	} else {
 		return i
 	}
 }
+
+func sign[T constraints.Signed](i T) T {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	default:
+		return 0
+	}
+}
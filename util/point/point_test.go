@@ -21,3 +21,45 @@ func TestPoint(t *testing.T) {
 		t.Error(adj)
 	}
 }
+
+func TestPointVector(t *testing.T) {
+	p1, p2 := Point{1, 2}, Point{4, -2}
+	for _, v := range []struct {
+		p, expected Point
+	}{
+		{p1.Add(p2), Point{5, 0}},
+		{p2.Sub(p1), Point{3, -4}},
+		{p1.Scale(3), Point{3, 6}},
+		{p1.Neg(), Point{-1, -2}},
+		{p2.Sub(p1).Sign(), Point{1, -1}},
+		{Point{0, 5}.Sign(), Point{0, 1}},
+		{p2.RotateRight(p1), Point{5, 5}},
+		{p2.RotateLeft(p1), Point{-3, -1}},
+		{p2.RotateRight(p1).RotateLeft(p1), p2},
+		{Point{1, 0}.RotateRight(Point{}), Point{0, 1}},
+	} {
+		if v.p != v.expected {
+			t.Error(v)
+		}
+	}
+	if p1.Dot(p2) != 0 || p1.Cross(p2) != -10 {
+		t.Error(p1.Dot(p2), p1.Cross(p2))
+	}
+}
+
+func TestPointMetrics(t *testing.T) {
+	p1, p2 := Point{1, 2}, Point{4, -2}
+	if p1.Distance(p2) != 7 || p1.Chebyshev(p2) != 4 || p1.Euclidean(p2) != 5 {
+		t.Error(p1.Distance(p2), p1.Chebyshev(p2), p1.Euclidean(p2))
+	}
+}
+
+func TestPointInt64(t *testing.T) {
+	p1, p2 := Pt[int64]{-4000000000, 0}, Pt[int64]{4000000000, 1}
+	if p1.Distance(p2) != 8000000001 || p1.Sub(p2).Sign() != (Pt[int64]{-1, -1}) {
+		t.Error(p1.Distance(p2))
+	}
+	if p := p1.Step(E, 3); p != (Pt[int64]{-3999999997, 0}) {
+		t.Error(p)
+	}
+}
@@ -0,0 +1,128 @@
+package point
+
+import "fmt"
+
+// Rectangle with inclusive bounds (empty if Min > Max)
+type Rect struct {
+	Min, Max Point
+}
+
+var emptyRect = Rect{Point{0, 0}, Point{-1, -1}}
+
+func NewRect(x0, y0, x1, y1 int) Rect {
+	return Rect{Point{x0, y0}, Point{x1, y1}}
+}
+
+// Bounding box of points
+func FromPoints(pp ...Point) Rect {
+	r := emptyRect
+	for _, p := range pp {
+		r = r.Include(p)
+	}
+	return r
+}
+
+func (r Rect) String() string {
+	if r.Empty() {
+		return "[]"
+	}
+	return fmt.Sprintf("[%d,%d -> %d,%d]", r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
+}
+
+func (r Rect) Empty() bool {
+	return r.Min.X > r.Max.X || r.Min.Y > r.Max.Y
+}
+
+func (r Rect) Width() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.Max.X - r.Min.X + 1
+}
+
+func (r Rect) Height() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.Max.Y - r.Min.Y + 1
+}
+
+func (r Rect) Area() int {
+	return r.Width() * r.Height()
+}
+
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Extend to include p
+func (r Rect) Include(p Point) Rect {
+	if r.Empty() {
+		return Rect{p, p}
+	}
+	return Rect{Point{min(r.Min.X, p.X), min(r.Min.Y, p.Y)}, Point{max(r.Max.X, p.X), max(r.Max.Y, p.Y)}}
+}
+
+func (r Rect) Intersect(r2 Rect) Rect {
+	out := Rect{Point{max(r.Min.X, r2.Min.X), max(r.Min.Y, r2.Min.Y)}, Point{min(r.Max.X, r2.Max.X), min(r.Max.Y, r2.Max.Y)}}
+	if out.Empty() {
+		return emptyRect
+	}
+	return out
+}
+
+// Bounding box of both rectangles
+func (r Rect) Union(r2 Rect) Rect {
+	if r.Empty() {
+		return r2
+	}
+	if r2.Empty() {
+		return r
+	}
+	return r.Include(r2.Min).Include(r2.Max)
+}
+
+// Grow by n on each side (shrink if n < 0)
+func (r Rect) Expand(n int) Rect {
+	if r.Empty() {
+		return r
+	}
+	out := Rect{r.Min.Move(-n, -n), r.Max.Move(n, n)}
+	if out.Empty() {
+		return emptyRect
+	}
+	return out
+}
+
+// Nearest point in r to p
+func (r Rect) Clip(p Point) Point {
+	return Point{max(r.Min.X, min(p.X, r.Max.X)), max(r.Min.Y, min(p.Y, r.Max.Y))}
+}
+
+// Call f for each point (row by row)
+func (r Rect) Each(f func(Point)) {
+	for y := r.Min.Y; y <= r.Max.Y; y++ {
+		for x := r.Min.X; x <= r.Max.X; x++ {
+			f(Point{x, y})
+		}
+	}
+}
+
+func (r Rect) Points() (out []Point) {
+	r.Each(func(p Point) { out = append(out, p) })
+	return
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,53 @@
+package point
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestRect(t *testing.T) {
+	r := FromPoints(Point{3, -1}, Point{0, 2}, Point{1, 1})
+	if r != NewRect(0, -1, 3, 2) || r.Width() != 4 || r.Height() != 4 || r.Area() != 16 {
+		t.Error(r)
+	}
+	if !r.Contains(Point{0, -1}) || !r.Contains(Point{3, 2}) || r.Contains(Point{4, 0}) {
+		t.Error("Contains")
+	}
+	e := FromPoints()
+	if !e.Empty() || e.Area() != 0 || e.Contains(Point{0, 0}) || e.String() != "[]" {
+		t.Error(e)
+	}
+	if r.String() != "[0,-1 -> 3,2]" {
+		t.Error(r.String())
+	}
+}
+
+func TestRectOps(t *testing.T) {
+	r1, r2 := NewRect(0, 0, 3, 3), NewRect(2, 1, 5, 2)
+	for _, v := range []struct{ r, expected Rect }{
+		{r1.Intersect(r2), NewRect(2, 1, 3, 2)},
+		{r1.Union(r2), NewRect(0, 0, 5, 3)},
+		{r1.Union(FromPoints()), r1},
+		{FromPoints().Union(r2), r2},
+		{r1.Expand(1), NewRect(-1, -1, 4, 4)},
+		{r2.Expand(-1), NewRect(3, 2, 4, 1)},
+		{r1.Include(Point{-2, 1}), NewRect(-2, 0, 3, 3)},
+	} {
+		if v.r != v.expected && !(v.r.Empty() && v.expected.Empty()) {
+			t.Error(v)
+		}
+	}
+	if !r1.Intersect(NewRect(10, 10, 11, 11)).Empty() {
+		t.Error("Intersect")
+	}
+	if p := r1.Clip(Point{-5, 2}); p != (Point{0, 2}) {
+		t.Error(p)
+	}
+	if p := r1.Clip(Point{7, 9}); p != (Point{3, 3}) {
+		t.Error(p)
+	}
+	if pp := NewRect(0, 0, 1, 1).Points(); !slices.Equal(pp, []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}}) {
+		t.Error(pp)
+	}
+}
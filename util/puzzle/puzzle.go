@@ -0,0 +1,248 @@
+package puzzle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/paulc/aoc2022/util"
+)
+
+// Examples extracted from a saved puzzle page
+type Page struct {
+	Title string
+	Parts []Part
+}
+
+type Part struct {
+	Examples []string // <pre><code> blocks
+	Answer   string   // Last emphasised <code><em> in the part (see Parse)
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	titleRe   = regexp.MustCompile(`(?s)<h2[^>]*>---\s*(.*?)\s*---</h2>`)
+	preRe     = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerRe  = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+)
+
+func text(s string) string {
+	return html.UnescapeString(tagRe.ReplaceAllString(s, ""))
+}
+
+// Parse saved puzzle HTML (one article per part). Parts usually emphasise
+// intermediate values as well as the answer so the answer is taken to be the
+// last emphasised code in the part - check the generated cases against the
+// puzzle text.
+func Parse(r io.Reader) (*Page, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	articles := articleRe.FindAllStringSubmatch(string(b), -1)
+	if len(articles) == 0 {
+		return nil, errors.New("No puzzle description found")
+	}
+	p := &Page{}
+	if m := titleRe.FindStringSubmatch(articles[0][1]); m != nil {
+		p.Title = text(m[1])
+	}
+	for _, a := range articles {
+		part := Part{}
+		for _, m := range preRe.FindAllStringSubmatch(a[1], -1) {
+			part.Examples = append(part.Examples, text(m[1]))
+		}
+		if m := answerRe.FindAllStringSubmatch(a[1], -1); m != nil {
+			last := m[len(m)-1]
+			part.Answer = text(last[1] + last[2])
+		}
+		p.Parts = append(p.Parts, part)
+	}
+	return p, nil
+}
+
+func ParseFile(name string) (*Page, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Test case for a single part
+type Case struct {
+	Name     string // part1/part2
+	Example  string // Testdata file
+	Expected string
+}
+
+// Cases for parts with an answer. The first example in each part is used
+// (later parts without examples reuse the previous example).
+func (p *Page) Cases() (out []Case) {
+	example := ""
+	for i, part := range p.Parts {
+		if len(part.Examples) > 0 {
+			example = fmt.Sprintf("example%d.txt", i+1)
+		}
+		if example != "" && part.Answer != "" {
+			out = append(out, Case{fmt.Sprintf("part%d", i+1), example, part.Answer})
+		}
+	}
+	return
+}
+
+// Write examples to dir/testdata/exampleN.txt (first example of each part)
+func (p *Page) WriteTestdata(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0o755); err != nil {
+		return err
+	}
+	for i, part := range p.Parts {
+		if len(part.Examples) == 0 {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, "testdata", fmt.Sprintf("example%d.txt", i+1)), []byte(part.Examples[0]), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by cmd/examples from "{{.Title}}"; DO NOT EDIT.
+
+package main
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata
+var examples embed.FS
+
+{{- range .Skipped}}
+// Skipped: {{.}}
+{{- end}}
+func TestExamples(t *testing.T) {
+	for _, v := range []struct {
+		name, example, expected string
+		part                    func(string) any
+	}{
+{{- range .Cases}}
+		{"{{.Name}}", "{{.Example}}", {{printf "%q" .Expected}}, func(s string) any { return {{.Name}}(parseInput(strings.NewReader(s))) }},
+{{- end}}
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			b, err := examples.ReadFile("testdata/" + v.example)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := fmt.Sprint(v.part(strings.TrimRight(string(b), "\n"))); result != v.expected {
+				t.Errorf("%s: got %s, expected %s", v.example, result, v.expected)
+			}
+		})
+	}
+}
+`))
+
+// Function declarations from the (non-test) Go files in dir
+type Funcs map[string]*ast.FuncType
+
+func ReadFuncs(dir string) (Funcs, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	out := Funcs{}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil {
+				out[fn.Name.Name] = fn.Type
+			}
+		}
+	}
+	return out, nil
+}
+
+func count(fields *ast.FieldList) (n int) {
+	if fields == nil {
+		return 0
+	}
+	for _, f := range fields.List {
+		n += util.Max(len(f.Names), 1)
+	}
+	return
+}
+
+// Check part can be called as part(parseInput(r)) - parts which need extra
+// arguments (eg. day15) can't be generated
+func (f Funcs) Callable(part string) error {
+	parse, ok := f["parseInput"]
+	if !ok {
+		return errors.New("No parseInput function")
+	}
+	fn, ok := f[part]
+	if !ok {
+		return fmt.Errorf("No %s function", part)
+	}
+	if count(fn.Params) != count(parse.Results) {
+		return fmt.Errorf("%s takes %d arguments (parseInput returns %d)", part, count(fn.Params), count(parse.Results))
+	}
+	return nil
+}
+
+// Generate table test calling part1/part2(parseInput(r)) for each case. If
+// funcs is not nil cases which can't be called are skipped (with a comment
+// in the generated test).
+func (p *Page) WriteTest(w io.Writer, funcs Funcs) error {
+	var cases []Case
+	var skipped []string
+	for _, c := range p.Cases() {
+		if funcs != nil {
+			if err := funcs.Callable(c.Name); err != nil {
+				skipped = append(skipped, err.Error())
+				continue
+			}
+		}
+		cases = append(cases, c)
+	}
+	if len(cases) == 0 {
+		return errors.New(strings.Join(append([]string{"No examples with answers found"}, skipped...), ": "))
+	}
+	var b bytes.Buffer
+	if err := testTemplate.Execute(&b, struct {
+		Title   string
+		Cases   []Case
+		Skipped []string
+	}{strings.ReplaceAll(p.Title, `"`, `'`), cases, skipped}); err != nil {
+		return err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,169 @@
+package puzzle
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	p, err := ParseFile("testdata/day01.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Title != "Day 1: Calorie Counting" || len(p.Parts) != 2 {
+		t.Fatal(p)
+	}
+	if len(p.Parts[0].Examples) != 1 || !strings.HasPrefix(p.Parts[0].Examples[0], "1000\n2000") || !strings.HasSuffix(p.Parts[0].Examples[0], "10000\n") {
+		t.Error(p.Parts[0].Examples)
+	}
+	if p.Parts[0].Answer != "24000" || p.Parts[1].Answer != "45000" || len(p.Parts[1].Examples) != 0 {
+		t.Error(p.Parts)
+	}
+	cases := p.Cases()
+	if len(cases) != 2 || cases[1] != (Case{"part2", "example1.txt", "45000"}) {
+		t.Error(cases)
+	}
+	if _, err := Parse(strings.NewReader("<html></html>")); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestEntities(t *testing.T) {
+	p, err := Parse(strings.NewReader(`<article class="day-desc"><h2>--- Day 9: X ---</h2><pre><code>a &lt; <em>b</em> &amp;&amp; c
+</code></pre><p><code><em>&quot;ok&quot;</em></code></p></article>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Parts[0].Examples[0] != "a < b && c\n" || p.Parts[0].Answer != `"ok"` {
+		t.Error(p.Parts)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	p, err := ParseFile("testdata/day01.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := p.WriteTestdata(dir); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "testdata", "example1.txt")); err != nil || string(b) != p.Parts[0].Examples[0] {
+		t.Error(string(b), err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "testdata", "example2.txt")); err == nil {
+		t.Error("Unexpected example2.txt")
+	}
+	var b bytes.Buffer
+	if err := p.WriteTest(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"part1", "example1.txt", "24000"`, `return part2(parseInput(strings.NewReader(s)))`} {
+		if !strings.Contains(b.String(), s) {
+			t.Error("Missing", s)
+		}
+	}
+	if err := (&Page{Parts: []Part{{}}}).WriteTest(&b, nil); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestCallable(t *testing.T) {
+	funcs, err := ReadFuncs("../../day15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := funcs.Callable("part1"); err == nil || !strings.Contains(err.Error(), "part1 takes 2 arguments") {
+		t.Error(err)
+	}
+	funcs, err = ReadFuncs("../../day05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := funcs.Callable("part2"); err != nil {
+		t.Error(err)
+	}
+	if err := funcs.Callable("part3"); err == nil {
+		t.Error("Expected error")
+	}
+	p := &Page{Parts: []Part{{Examples: []string{"x"}, Answer: "1"}}}
+	funcs, _ = ReadFuncs("../../day15")
+	if err := p.WriteTest(io.Discard, funcs); err == nil || !strings.Contains(err.Error(), "part1 takes") {
+		t.Error(err)
+	}
+}
+
+const day05 = `<article class="day-desc"><h2>--- Day 5: Supply Stacks ---</h2>
+<pre><code>    [D]    
+[N] [C]    
+[Z] [M] [P]
+ 1   2   3 
+
+move 1 from 2 to 1
+move 3 from 1 to 3
+move 2 from 2 to 1
+move 1 from 1 to 2
+</code></pre>
+<p>The crates end up as <code><em>CMZ</em></code>.</p></article>
+<article class="day-desc"><p>Now <code><em>MCD</em></code>.</p></article>`
+
+// Generate examples for real days and run the generated tests
+func TestGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping go test of generated code")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+	for _, v := range []struct {
+		day  string
+		page func() (*Page, error)
+	}{
+		{"day01", func() (*Page, error) { return ParseFile("testdata/day01.html") }},
+		{"day05", func() (*Page, error) { return Parse(strings.NewReader(day05)) }},
+	} {
+		t.Run(v.day, func(t *testing.T) {
+			p, err := v.page()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Generated package must be inside the module (testdata is
+			// ignored by ./...)
+			dir, err := os.MkdirTemp("testdata", "gen-"+v.day+"-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			src, err := os.ReadFile(filepath.Join("../..", v.day, "main.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			funcs, err := ReadFuncs(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			if err := p.WriteTestdata(dir); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.WriteTest(&b, funcs); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "examples_test.go"), b.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if out, err := exec.Command(gobin, "test", "./"+filepath.ToSlash(dir)).CombinedOutput(); err != nil {
+				t.Errorf("%s\n%s", err, out)
+			}
+		})
+	}
+}
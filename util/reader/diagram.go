@@ -0,0 +1,154 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+)
+
+// Column-aligned diagram with a label row below the cells eg.
+//
+//	    [D]
+//	[N] [C]
+//	[Z] [M] [P]
+//	 1   2   3
+//
+// Cells are located by overlap with the labels (so lines may be right
+// trimmed and labels may be wider than one character). Cells are runs of
+// non-space characters or bracketed text (which may contain spaces) and
+// surrounding brackets are removed. Columns are counted in runes.
+//
+// Columns holds the cells as stacks with any gaps dropped - use Rows where
+// the vertical position of a cell matters.
+type Diagram struct {
+	Labels  []string
+	Columns [][]string // Non-empty cells for each label from the bottom up
+	Rows    [][]string // Cells for each line above the labels from the top down ("" if empty)
+}
+
+var brackets = map[rune]rune{'[': ']', '(': ')', '{': '}', '<': '>'}
+
+type cell struct {
+	start, end int // Rune offsets
+	text       string
+}
+
+// Split line into cells (bracketed text or runs of non-space characters)
+func cells(line string) (out []cell) {
+	r := []rune(line)
+	for i := 0; i < len(r); {
+		if unicode.IsSpace(r[i]) {
+			i++
+			continue
+		}
+		start := i
+		if close, ok := brackets[r[i]]; ok {
+			if j := slices.Index(r[i+1:], close); j >= 0 {
+				i += j + 2
+				out = append(out, cell{start, i, string(r[start+1 : i-1])})
+				continue
+			}
+		}
+		for i < len(r) && !unicode.IsSpace(r[i]) {
+			i++
+		}
+		out = append(out, cell{start, i, string(r[start:i])})
+	}
+	return
+}
+
+// Parse diagram (the last non-blank line is the label row)
+func ParseDiagram(lines []string) (*Diagram, error) {
+	last := len(lines) - 1
+	for last >= 0 && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	if last < 0 {
+		return nil, errors.New("Empty diagram")
+	}
+	labels := cells(lines[last])
+	d := &Diagram{Columns: make([][]string, len(labels)), Rows: make([][]string, last)}
+	for _, l := range labels {
+		d.Labels = append(d.Labels, l.text)
+	}
+	for i := range d.Rows {
+		d.Rows[i] = make([]string, len(labels))
+	}
+	for i := last - 1; i >= 0; i-- {
+		for _, c := range cells(lines[i]) {
+			col := -1
+			for j, l := range labels {
+				if c.start < l.end && l.start < c.end {
+					if col >= 0 {
+						return nil, &ParseError{Line: i + 1, Column: c.start + 1, Text: lines[i], Err: fmt.Errorf("Cell spans columns %s and %s", d.Labels[col], d.Labels[j])}
+					}
+					col = j
+				}
+			}
+			if col < 0 {
+				return nil, &ParseError{Line: i + 1, Column: c.start + 1, Text: lines[i], Err: errors.New("Cell not under a label")}
+			}
+			d.Columns[col] = append(d.Columns[col], c.text)
+			d.Rows[i][col] = c.text
+		}
+	}
+	return d, nil
+}
+
+func centre(s string, width int) string {
+	pad := width - utf8.RuneCountInString(s)
+	return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+}
+
+// Render stacks (bottom up) in the same layout as ParseDiagram. Labels
+// default to 1..n if nil.
+func RenderStacks[S ~[]T, T any](labels []string, stacks []S) (string, error) {
+	if labels == nil {
+		for i := range stacks {
+			labels = append(labels, fmt.Sprint(i+1))
+		}
+	}
+	if len(labels) != len(stacks) {
+		return "", fmt.Errorf("%d labels for %d stacks", len(labels), len(stacks))
+	}
+	width, height := make([]int, len(stacks)), 0
+	rendered := make([][]string, len(stacks))
+	for i, s := range stacks {
+		width[i] = utf8.RuneCountInString(labels[i])
+		if width[i] < 3 { // Single character cell
+			width[i] = 3
+		}
+		for _, v := range s {
+			c := "[" + fmt.Sprint(v) + "]"
+			rendered[i] = append(rendered[i], c)
+			if n := utf8.RuneCountInString(c); n > width[i] {
+				width[i] = n
+			}
+		}
+		if len(s) > height {
+			height = len(s)
+		}
+	}
+	out := []string{}
+	row := func(f func(i int) string) {
+		r := []string{}
+		for i := range stacks {
+			r = append(r, centre(f(i), width[i]))
+		}
+		out = append(out, strings.TrimRight(strings.Join(r, " "), " "))
+	}
+	for y := height - 1; y >= 0; y-- {
+		row(func(i int) string {
+			if y < len(rendered[i]) {
+				return rendered[i][y]
+			}
+			return ""
+		})
+	}
+	row(func(i int) string { return labels[i] })
+	return strings.Join(out, "\n"), nil
+}
@@ -0,0 +1,93 @@
+package reader
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const diagram = `    [D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3`
+
+func TestParseDiagram(t *testing.T) {
+	d, err := ParseDiagram(strings.Split(diagram, "\n"))
+	if err != nil ||
+		!reflect.DeepEqual(d.Labels, []string{"1", "2", "3"}) ||
+		!reflect.DeepEqual(d.Columns, [][]string{{"Z", "N"}, {"M", "C", "D"}, {"P"}}) {
+		t.Error(d, err)
+	}
+	if s, err := RenderStacks(d.Labels, d.Columns); err != nil || s != diagram {
+		t.Errorf("\n%s %v", s, err)
+	}
+	if _, err := RenderStacks(d.Labels[:2], d.Columns); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestParseDiagramRows(t *testing.T) {
+	d, err := ParseDiagram([]string{"[A]", "", "[B] [C]", " 1   2"})
+	if err != nil ||
+		!reflect.DeepEqual(d.Columns, [][]string{{"B", "A"}, {"C"}}) ||
+		!reflect.DeepEqual(d.Rows, [][]string{{"A", ""}, {"", ""}, {"B", "C"}}) {
+		t.Error(d, err)
+	}
+}
+
+func TestParseDiagramWide(t *testing.T) {
+	stacks := [][]string{{"A"}, {}, {}, {}, {}, {}, {}, {}, {}, {"B", "C"}, {"D"}}
+	s, err := RenderStacks(nil, stacks)
+	if err != nil || !strings.HasSuffix(s, " 9  10  11") {
+		t.Errorf("\n%s", s)
+	}
+	d, err := ParseDiagram(append(strings.Split(s, "\n"), "", "  "))
+	if err != nil || !reflect.DeepEqual(d.Columns[9], stacks[9]) || !reflect.DeepEqual(d.Columns[10], stacks[10]) || len(d.Columns[1]) != 0 {
+		t.Error(d, err)
+	}
+	d, err = ParseDiagram([]string{"(x) <yy>  z", " a   b    c"})
+	if err != nil || !reflect.DeepEqual(d.Columns, [][]string{{"x"}, {"yy"}, {"z"}}) {
+		t.Error(d, err)
+	}
+}
+
+func TestParseDiagramBrackets(t *testing.T) {
+	// Bracketed cells may contain spaces (an unmatched bracket is part of a
+	// normal cell)
+	d, err := ParseDiagram([]string{"[A B] [C]  [D", "  x    y    z"})
+	if err != nil || !reflect.DeepEqual(d.Columns, [][]string{{"A B"}, {"C"}, {"[D"}}) {
+		t.Error(d, err)
+	}
+}
+
+func TestDiagramUnicode(t *testing.T) {
+	stacks := [][]string{{"α", "β"}, {"γ"}}
+	s, err := RenderStacks([]string{"é", "ü"}, stacks)
+	if err != nil || s != "[β]\n[α] [γ]\n é   ü" {
+		t.Errorf("%q %v", s, err)
+	}
+	d, err := ParseDiagram(strings.Split(s, "\n"))
+	if err != nil || !reflect.DeepEqual(d.Labels, []string{"é", "ü"}) || !reflect.DeepEqual(d.Columns, stacks) {
+		t.Error(d, err)
+	}
+}
+
+func TestParseDiagramError(t *testing.T) {
+	var pe *ParseError
+	for _, v := range []struct {
+		lines  []string
+		line   int
+		column int
+	}{
+		{[]string{"[A]    [B]", " 1   2"}, 1, 8},
+		{[]string{"[A] [BBBBB]", " 1   2   3"}, 1, 5},
+	} {
+		if _, err := ParseDiagram(v.lines); !errors.As(err, &pe) || pe.Line != v.line || pe.Column != v.column {
+			t.Error(v.lines, err)
+		}
+	}
+	if _, err := ParseDiagram([]string{" "}); err == nil {
+		t.Error("Expected error")
+	}
+}
@@ -0,0 +1,53 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error parsing input. Line and Column are 1-based (0 if not known) and Text
+// is the offending line. Err is available via errors.Is/As.
+type ParseError struct {
+	Line, Column int
+	Text         string
+	Err          error
+}
+
+func (e *ParseError) Error() string {
+	pos := []string{}
+	if e.Line > 0 {
+		pos = append(pos, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Column > 0 {
+		pos = append(pos, fmt.Sprintf("column %d", e.Column))
+	}
+	out := e.Err.Error()
+	if len(pos) > 0 {
+		out = strings.Join(pos, ", ") + ": " + out
+	}
+	if e.Text != "" {
+		out += fmt.Sprintf(" [%q]", e.Text)
+	}
+	return out
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap err as a ParseError for line (filling in any missing position from
+// an existing ParseError)
+func lineError(err error, line int, text string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		if pe.Line == 0 {
+			pe.Line = line
+		}
+		if pe.Text == "" {
+			pe.Text = text
+		}
+		return err
+	}
+	return &ParseError{Line: line, Text: text, Err: err}
+}
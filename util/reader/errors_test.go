@@ -0,0 +1,52 @@
+package reader
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	for _, v := range []struct {
+		err      *ParseError
+		expected string
+	}{
+		{&ParseError{Line: 3, Column: 5, Text: "a b", Err: errors.New("bad")}, `line 3, column 5: bad ["a b"]`},
+		{&ParseError{Line: 3, Err: errors.New("bad")}, `line 3: bad`},
+		{&ParseError{Column: 2, Err: errors.New("bad")}, `column 2: bad`},
+	} {
+		if v.err.Error() != v.expected {
+			t.Error(v.err)
+		}
+	}
+}
+
+func TestLineReaderError(t *testing.T) {
+	_, err := LineReader(strings.NewReader("1\n2\nx\n4"), func(s string) error {
+		_, err := strconv.Atoi(s)
+		return err
+	})
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 3 || pe.Text != "x" || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error(err)
+	}
+	// Column from an existing ParseError is kept
+	_, err = LineReader(strings.NewReader("1\n2"), func(s string) error {
+		if s == "2" {
+			return &ParseError{Column: 4, Err: ErrNoMatch}
+		}
+		return nil
+	})
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 4 || pe.Text != "2" || !errors.Is(err, ErrNoMatch) {
+		t.Error(err)
+	}
+}
+
+func TestGroupReaderError(t *testing.T) {
+	_, err := GroupReader(strings.NewReader("1\n\n2\nx"), func(s string) bool { return s == "" }, strconv.Atoi)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 4 || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error(err)
+	}
+}
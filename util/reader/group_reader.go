@@ -4,19 +4,23 @@ import (
 	"io"
 )
 
+// Group lines separated by lines matching groupF (leading, repeated and
+// trailing separators don't create empty groups)
 func GroupReader[T any](r io.Reader, groupF func(string) bool, parseF func(string) (T, error)) (out [][]T, err error) {
-	i := 0
-	out = append(out, []T{})
+	split := true
 	_, err = LineReader(r, func(s string) error {
 		if groupF(s) {
-			out = append(out, []T{})
-			i += 1
+			split = true
 		} else {
 			v, err := parseF(s)
 			if err != nil {
 				return err
 			}
-			out[i] = append(out[i], v)
+			if split {
+				out = append(out, []T{})
+				split = false
+			}
+			out[len(out)-1] = append(out[len(out)-1], v)
 		}
 		return nil
 	})
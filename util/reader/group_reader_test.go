@@ -34,3 +34,25 @@ func TestGroupReader(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupReaderEmpty(t *testing.T) {
+	blank := func(s string) bool { return s == "" }
+	for _, v := range []struct {
+		in       string
+		expected [][]int
+	}{
+		{"\n1\n2\n\n\n3\n\n", [][]int{{1, 2}, {3}}},
+		{"\n\n", nil},
+		{"", nil},
+	} {
+		a, err := GroupReader(bytes.NewBufferString(v.in), blank, strconv.Atoi)
+		if err != nil || len(a) != len(v.expected) {
+			t.Fatal(v.in, a, err)
+		}
+		for i := range a {
+			if !slices.Equal(a[i], v.expected[i]) {
+				t.Error(v.in, a)
+			}
+		}
+	}
+}
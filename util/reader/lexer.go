@@ -0,0 +1,110 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	EOF TokenKind = iota
+	Number
+	Ident
+	Punct
+	String
+)
+
+var tokenNames = []string{"EOF", "number", "identifier", "punctuation", "string"}
+
+func (k TokenKind) String() string {
+	if k < 0 || int(k) >= len(tokenNames) {
+		return fmt.Sprintf("TokenKind(%d)", int(k))
+	}
+	return tokenNames[k]
+}
+
+// Token with byte offset into the input. Text for strings is the unquoted
+// value.
+type Token struct {
+	Kind TokenKind
+	Text string
+	Pos  int
+}
+
+func (t Token) String() string {
+	if t.Kind == EOF {
+		return "EOF"
+	}
+	return fmt.Sprintf("%s %q", t.Kind, t.Text)
+}
+
+func isIdent(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+// Directly following a number or identifier
+func follows(s string) bool {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r < utf8.RuneSelf && isDigit(byte(r)) || isIdent(r)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Split s into tokens:
+//
+//   - Numbers are runs of digits (with a leading - unless directly following
+//     a number or identifier so 2-4 lexes as 2 - 4)
+//   - Identifiers are runs of letters/underscores (digits start a new token
+//     so 10R5L5 lexes as 10 R 5 L 5)
+//   - Strings are double quoted (with Go escapes) or single quoted (raw)
+//   - Anything else (except whitespace) is a single character of punctuation
+func Lex(s string) (out []Token, err error) {
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i += n
+			continue
+		case isDigit(s[i]) || (s[i] == '-' && i+1 < len(s) && isDigit(s[i+1]) && !follows(s[:i])):
+			for i++; i < len(s) && isDigit(s[i]); i++ {
+			}
+			out = append(out, Token{Number, s[start:i], start})
+		case isIdent(r):
+			for i += n; i < len(s); i += n {
+				if r, n = utf8.DecodeRuneInString(s[i:]); !isIdent(r) {
+					break
+				}
+			}
+			out = append(out, Token{Ident, s[start:i], start})
+		case r == '"' || r == '\'':
+			j := i + 1
+			for ; j < len(s) && s[j] != s[i]; j++ {
+				if s[j] == '\\' && r == '"' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, &ParseError{Column: start + 1, Text: s, Err: errors.New("Unterminated string")}
+			}
+			i = j + 1
+			v := s[start+1 : i-1]
+			if r == '"' {
+				if v, err = strconv.Unquote(s[start:i]); err != nil {
+					return nil, &ParseError{Column: start + 1, Text: s, Err: err}
+				}
+			}
+			out = append(out, Token{String, v, start})
+		default:
+			i += n
+			out = append(out, Token{Punct, s[start:i], start})
+		}
+	}
+	return
+}
@@ -0,0 +1,40 @@
+package reader
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestLex(t *testing.T) {
+	for _, v := range []struct {
+		in       string
+		expected []Token
+	}{
+		{"move 1 from -2", []Token{{Ident, "move", 0}, {Number, "1", 5}, {Ident, "from", 7}, {Number, "-2", 12}}},
+		{"10R5L5", []Token{{Number, "10", 0}, {Ident, "R", 2}, {Number, "5", 3}, {Ident, "L", 4}, {Number, "5", 5}}},
+		{"2-4,x-1", []Token{{Number, "2", 0}, {Punct, "-", 1}, {Number, "4", 2}, {Punct, ",", 3}, {Ident, "x", 4}, {Punct, "-", 5}, {Number, "1", 6}}},
+		{`say "a \"b\"" 'c\d'`, []Token{{Ident, "say", 0}, {String, `a "b"`, 4}, {String, `c\d`, 14}}},
+		{"é_x→", []Token{{Ident, "é_x", 0}, {Punct, "→", 4}}},
+		{"  ", nil},
+	} {
+		out, err := Lex(v.in)
+		if err != nil || !slices.Equal(out, v.expected) {
+			t.Error(v.in, out, err)
+		}
+	}
+	var pe *ParseError
+	if _, err := Lex(`ok "unterminated`); !errors.As(err, &pe) || pe.Column != 4 {
+		t.Error(err)
+	}
+	if _, err := Lex(`"bad \q"`); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestTokenKind(t *testing.T) {
+	if Ident.String() != "identifier" || TokenKind(9).String() != "TokenKind(9)" || TokenKind(-1).String() != "TokenKind(-1)" {
+		t.Error(Ident, TokenKind(9), TokenKind(-1))
+	}
+}
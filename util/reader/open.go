@@ -0,0 +1,125 @@
+package reader
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Decompressors by file extension
+var Decompressors = map[string]func(io.Reader) (io.Reader, error){
+	".gz":  func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+	".bz2": func(r io.Reader) (io.Reader, error) { return bzip2.NewReader(r), nil },
+	".zst": func(r io.Reader) (io.Reader, error) {
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	},
+}
+
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiCloser) Close() (err error) {
+	for _, c := range m.closers {
+		if e := c.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// Wrap rc with a decompressor based on the extension of name
+func decompress(name string, rc io.ReadCloser) (io.ReadCloser, error) {
+	ext := path.Ext(name)
+	f, ok := Decompressors[ext]
+	if !ok {
+		return rc, nil
+	}
+	r, err := f(rc)
+	if err != nil {
+		rc.Close()
+		return nil, fmt.Errorf("Error decompressing <%s>: %w", name, err)
+	}
+	out := &multiCloser{Reader: r}
+	if c, ok := r.(io.Closer); ok {
+		out.closers = append(out.closers, c)
+	}
+	out.closers = append(out.closers, rc)
+	return out, nil
+}
+
+// Decode RFC 2397 data URL (data:[<mediatype>][;base64],<data>)
+func dataOpen(arg string) (io.ReadCloser, error) {
+	header, data, found := strings.Cut(strings.TrimPrefix(arg, "data:"), ",")
+	if !found {
+		return nil, fmt.Errorf("Invalid data URL: %s", arg)
+	}
+	var b []byte
+	var err error
+	if strings.HasSuffix(header, ";base64") {
+		b, err = base64.StdEncoding.DecodeString(data)
+	} else {
+		var s string
+		s, err = url.PathUnescape(data)
+		b = []byte(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid data URL: %s", err)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+var (
+	filesystems   = map[string]fs.FS{}
+	filesystemsMu sync.Mutex
+)
+
+// Register filesystem (eg. embed.FS) so UrlOpen can read fs://name/path
+func RegisterFS(name string, fsys fs.FS) {
+	filesystemsMu.Lock()
+	defer filesystemsMu.Unlock()
+	filesystems[name] = fsys
+}
+
+// Open file from fsys (decompressing by extension)
+func FSOpen(fsys fs.FS, name string) (io.ReadCloser, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(name, f)
+}
+
+func fsOpen(target *url.URL) (io.ReadCloser, error) {
+	filesystemsMu.Lock()
+	fsys, ok := filesystems[target.Host]
+	filesystemsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Unknown filesystem: %s", target.Host)
+	}
+	return FSOpen(fsys, strings.TrimPrefix(target.Path, "/"))
+}
+
+func fileOpen(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(name, f)
+}
@@ -0,0 +1,119 @@
+package reader
+
+import (
+	"embed"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/paulc/aoc2022/util"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func readAll(t *testing.T, arg string) string {
+	t.Helper()
+	r, err := UrlOpen(arg)
+	if err != nil {
+		t.Fatal(arg, err)
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(arg, err)
+	}
+	return string(b)
+}
+
+func TestUrlOpenCompressed(t *testing.T) {
+	for _, arg := range []string{"testdata/test.txt.gz", "testdata/test.txt.bz2", "testdata/test.txt.zst", "file://" + util.Must(os.Getwd()) + "/testdata/test.txt.gz"} {
+		if s := readAll(t, arg); s != contents {
+			t.Error(arg, s)
+		}
+	}
+	if _, err := UrlOpen("testdata/missing.txt.zst"); err == nil {
+		t.Error("Expected error")
+	}
+	// Not compressed
+	f, _ := os.CreateTemp(t.TempDir(), "*.zst")
+	f.WriteString(contents)
+	f.Close()
+	r, err := UrlOpen(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := io.ReadAll(r); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestUrlOpenData(t *testing.T) {
+	for _, v := range []struct{ arg, expected string }{
+		{"data:,1%202%0A3", "1 2\n3"},
+		{"data:text/plain;charset=utf-8,abc", "abc"},
+		{"data:text/plain;base64,aGVsbG8K", "hello\n"},
+	} {
+		if s := readAll(t, v.arg); s != v.expected {
+			t.Error(v.arg, s)
+		}
+	}
+	for _, arg := range []string{"data:text/plain", "data:;base64,***"} {
+		if _, err := UrlOpen(arg); err == nil {
+			t.Error("Expected error", arg)
+		}
+	}
+}
+
+func TestUrlOpenFS(t *testing.T) {
+	RegisterFS("testdata", testdata)
+	RegisterFS("mem", fstest.MapFS{"input": {Data: []byte("1\n2\n")}})
+	if s := readAll(t, "fs://testdata/testdata/test.txt.bz2"); s != contents {
+		t.Error(s)
+	}
+	if s := readAll(t, "fs://mem/input"); s != "1\n2\n" {
+		t.Error(s)
+	}
+	if _, err := UrlOpen("fs://unknown/input"); err == nil {
+		t.Error("Expected error")
+	}
+	r, err := FSOpen(testdata, "testdata/test.txt.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines, _ := Lines(r); len(lines) != 3 {
+		t.Error(lines)
+	}
+}
+
+func TestUrlOpenStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+	os.Stdin = r
+	go func() {
+		w.WriteString("from stdin")
+		w.Close()
+	}()
+	if s := readAll(t, "-"); s != "from stdin" {
+		t.Error(s)
+	}
+}
+
+func TestUrlOpenHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.FS(testdata)))
+	defer srv.Close()
+	if s := readAll(t, srv.URL+"/testdata/test.txt.gz"); s != contents {
+		t.Error(s)
+	}
+	if r, err := UrlOpen(srv.URL + "/testdata/missing.txt"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Error(r, err)
+	}
+}
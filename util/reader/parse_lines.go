@@ -0,0 +1,203 @@
+package reader
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNoMatch = errors.New("Line doesn't match pattern")
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// Parse lines into struct T using either a template with {Field} placeholders
+// (eg. "Sensor at x={X}, y={Y}") or a regexp with named groups. Fields are
+// matched by name or by a `parse:"name"` tag and must be exported. Supported
+// field types are strings, numbers, bools, encoding.TextUnmarshaler and
+// slices of these (comma separated).
+type RecordParser[T any] struct {
+	re       *regexp.Regexp
+	prefixes []*regexp.Regexp // Template prefixes used to locate mismatches
+	fields   map[string]int
+}
+
+func NewRecordParser[T any](pattern string) (*RecordParser[T], error) {
+	t := reflect.TypeOf(*new(T))
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Invalid record type: %v", t)
+	}
+	p := &RecordParser[T]{fields: map[string]int{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("parse"); ok {
+			name = tag
+		}
+		p.fields[name] = i
+	}
+	groups := []string{}
+	if strings.Contains(pattern, "(?P<") {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, err
+		}
+		p.re = re
+		groups = re.SubexpNames()
+	} else {
+		expr := "^"
+		last := 0
+		for _, m := range placeholder.FindAllStringSubmatchIndex(pattern, -1) {
+			name := pattern[m[2]:m[3]]
+			i, ok := p.fields[name]
+			if !ok {
+				return nil, fmt.Errorf("Unknown field: %s", name)
+			}
+			expr += regexp.QuoteMeta(pattern[last:m[0]])
+			p.prefixes = append(p.prefixes, regexp.MustCompile(expr))
+			expr += fmt.Sprintf(`(?P<%s>%s)`, name, fieldPattern(t.Field(i).Type))
+			p.prefixes = append(p.prefixes, regexp.MustCompile(expr))
+			groups = append(groups, name)
+			last = m[1]
+		}
+		expr += regexp.QuoteMeta(pattern[last:])
+		p.prefixes = append(p.prefixes, regexp.MustCompile(expr))
+		p.re = regexp.MustCompile(expr + "$")
+	}
+	for _, name := range groups {
+		if name == "" {
+			continue
+		}
+		i, ok := p.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown field: %s", name)
+		}
+		if !t.Field(i).IsExported() {
+			return nil, fmt.Errorf("Unexported field: %s", name)
+		}
+	}
+	return p, nil
+}
+
+// Match numbers strictly so that the surrounding literals line up
+func fieldPattern(t reflect.Type) string {
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		return `.*?`
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return `[+-]?\d+`
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return `\d+`
+	case reflect.Float32, reflect.Float64:
+		return `[+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?`
+	}
+	return `.*?`
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Parse single line - errors are returned as a *ParseError with the column
+// (for regexp patterns the column of a mismatch isn't known so isn't set)
+func (p *RecordParser[T]) Parse(s string) (out T, err error) {
+	m := p.re.FindStringSubmatchIndex(s)
+	if m == nil {
+		pe := &ParseError{Text: s, Err: ErrNoMatch}
+		if p.prefixes != nil {
+			pe.Column = p.mismatch(s) + 1
+		}
+		return out, pe
+	}
+	v := reflect.ValueOf(&out).Elem()
+	for i, name := range p.re.SubexpNames() {
+		if name == "" || m[2*i] < 0 {
+			continue
+		}
+		if err := setField(v.Field(p.fields[name]), s[m[2*i]:m[2*i+1]]); err != nil {
+			return out, &ParseError{Column: m[2*i] + 1, Text: s, Err: fmt.Errorf("%s: %w", name, err)}
+		}
+	}
+	return out, nil
+}
+
+// Offset of the first character which doesn't match the template
+func (p *RecordParser[T]) mismatch(s string) (offset int) {
+	for _, re := range p.prefixes {
+		m := re.FindStringIndex(s)
+		if m == nil {
+			break
+		}
+		offset = m[1]
+	}
+	return
+}
+
+func setField(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		parts := strings.Split(s, ",")
+		out := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, e := range parts {
+			if err := setField(out.Index(i), strings.TrimSpace(e)); err != nil {
+				return err
+			}
+		}
+		v.Set(out)
+	default:
+		return fmt.Errorf("Unsupported field type: %v", v.Type())
+	}
+	return nil
+}
+
+// Parse each (non-empty) line of r into T (see RecordParser)
+func ParseLines[T any](r io.Reader, pattern string) (out []T, err error) {
+	p, err := NewRecordParser[T](pattern)
+	if err != nil {
+		return nil, err
+	}
+	_, err = LineReader(r, func(s string) error {
+		if len(s) == 0 {
+			return nil
+		}
+		v, err := p.Parse(s)
+		if err != nil {
+			return err
+		}
+		out = append(out, v)
+		return nil
+	})
+	return
+}
@@ -0,0 +1,107 @@
+package reader
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/point"
+	"golang.org/x/exp/slices"
+)
+
+type sensor struct {
+	X, Y   int
+	Beacon string `parse:"b"`
+}
+
+func TestParseLinesTemplate(t *testing.T) {
+	out, err := ParseLines[sensor](strings.NewReader("Sensor at x=-2, y=15: AB\n\nSensor at x=9, y=+16: C D\n"), "Sensor at x={X}, y={Y}: {b}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(out, []sensor{{-2, 15, "AB"}, {9, 16, "C D"}}) {
+		t.Error(out)
+	}
+}
+
+type valve struct {
+	Key   string
+	Flow  uint
+	Paths []string
+	Rate  float64
+}
+
+func TestParseLinesRegexp(t *testing.T) {
+	out, err := ParseLines[valve](strings.NewReader("Valve AA has flow rate=0; tunnels lead to valves DD, II, BB\nValve HH has flow rate=22; tunnel leads to valve GG"),
+		`Valve (?P<Key>\w+) has flow rate=(?P<Flow>\d+); tunnels? leads? to valves? (?P<Paths>.*)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[0].Key != "AA" || !slices.Equal(out[0].Paths, []string{"DD", "II", "BB"}) || out[1].Flow != 22 || !slices.Equal(out[1].Paths, []string{"GG"}) {
+		t.Error(out)
+	}
+}
+
+type move struct {
+	Dir   point.Dir `parse:"dir"`
+	N     int       `parse:"n"`
+	Tags  []string  `parse:"t"`
+	other int
+}
+
+func TestRecordParserTags(t *testing.T) {
+	out, err := ParseLines[move](strings.NewReader("R 4 [a,b]\nU -1 []"), "{dir} {n} [{t}]")
+	if err != nil || len(out) != 2 || out[0].Dir != point.E || out[0].N != 4 || !slices.Equal(out[0].Tags, []string{"a", "b"}) || out[1].Dir != point.N || out[1].N != -1 {
+		t.Error(out, err)
+	}
+	if _, err := NewRecordParser[move]("{dir} {other}"); err == nil || err.Error() != "Unexported field: other" {
+		t.Error(err)
+	}
+}
+
+func TestRecordParserErrors(t *testing.T) {
+	var pe *ParseError
+	p, err := NewRecordParser[sensor]("Sensor at x={X}, y={Y}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []struct {
+		in     string
+		column int
+		err    error
+	}{
+		{"Sensor at x=1, y=2", 0, nil},
+		{"Sensor at x=1; y=2", 14, ErrNoMatch},
+		{"Sensor at x=1, y=2!", 19, ErrNoMatch},
+		{"Sensor at x=99999999999999999999, y=2", 13, strconv.ErrRange},
+	} {
+		_, err := p.Parse(v.in)
+		if v.err == nil {
+			if err != nil {
+				t.Error(v.in, err)
+			}
+		} else if !errors.As(err, &pe) || pe.Column != v.column || pe.Text != v.in || !errors.Is(err, v.err) {
+			t.Error(v.in, err)
+		}
+	}
+	if _, err := p.Parse("x"); !errors.Is(err, ErrNoMatch) {
+		t.Error(err)
+	}
+	for _, pattern := range []string{"x={Z}", `(?P<Z>\d+)`, `(?P<X>\d+`} {
+		if _, err := NewRecordParser[sensor](pattern); err == nil {
+			t.Error("Expected error", pattern)
+		}
+	}
+	if _, err := NewRecordParser[sensor](`(?P<X>\d+),(?P<Y>\d+)`); err != nil {
+		t.Error(err)
+	} else if _, err := ParseLines[sensor](strings.NewReader("1,x"), `(?P<X>\d+),(?P<Y>\d+)`); !errors.As(err, &pe) || pe.Column != 0 || pe.Line != 1 {
+		t.Error("Expected no column for regexp mismatch", err)
+	}
+	if _, err := NewRecordParser[int]("{x}"); err == nil {
+		t.Error("Expected error (not struct)")
+	}
+	if _, err := ParseLines[sensor](strings.NewReader("Sensor at x=1, y=2\nbad"), "Sensor at x={X}, y={Y}"); err == nil || err.Error() != `line 2, column 1: Line doesn't match pattern ["bad"]` {
+		t.Error(err)
+	}
+}
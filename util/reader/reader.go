@@ -1,47 +1,63 @@
 package reader
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 )
 
-// Open file or http URL
+// Open file or URL - supports plain paths, "-" (stdin), file://, http(s)://,
+// data: URLs and fs://name/path (see RegisterFS). Files are decompressed
+// based on the extension (see Decompressors).
 func UrlOpen(arg string) (io.ReadCloser, error) {
+	if arg == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	if strings.HasPrefix(arg, "data:") {
+		return dataOpen(arg)
+	}
+
 	target, err := url.Parse(arg)
 	if err != nil {
 		return nil, fmt.Errorf("Couldnt parse URL <%s> %s", arg, err)
 	}
 
 	if target.Scheme == "" {
-		return os.Open(arg)
+		return fileOpen(arg)
 	} else if target.Scheme == "http" || target.Scheme == "https" {
 		resp, err := http.Get(arg)
 		if err != nil {
 			return nil, fmt.Errorf("Error fetching URL <%s>: %s", arg, err)
 		}
-		return resp.Body, nil
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("Error fetching URL <%s>: %s", arg, resp.Status)
+		}
+		return decompress(target.Path, resp.Body)
 	} else if target.Scheme == "file" {
-		return os.Open(target.Path)
+		return fileOpen(target.Path)
+	} else if target.Scheme == "fs" {
+		return fsOpen(target)
 	} else {
-		return nil, fmt.Errorf("Invalid URL scheme: %s (http/https/file supported)", arg)
+		return nil, fmt.Errorf("Invalid URL scheme: %s (http/https/file/fs/data supported)", arg)
 	}
 }
 
-// Call f for each line in io.Reader
-func LineReader(r io.Reader, f func(s string) error) (count int, err error) {
-	scanner := bufio.NewScanner(r)
+// Call f for each line in io.Reader - errors from f are returned as a
+// *ParseError with the line number
+func LineReader(r io.Reader, f func(s string) error, opts ...Option) (count int, err error) {
+	scanner := newScanner(r, opts)
 	for scanner.Scan() {
 		if err := f(scanner.Text()); err != nil {
-			return count, fmt.Errorf("Error calling line function: %s", err)
+			return count, lineError(err, count+1, scanner.Text())
 		}
 		count++
 	}
 	if err := scanner.Err(); err != nil {
-		return count, fmt.Errorf("Scanner Error: %s", err)
+		return count, fmt.Errorf("Scanner Error: %w", err)
 	}
 	return count, nil
 }
@@ -57,10 +73,10 @@ func URLReader(url string, f func(s string) error) (int, error) {
 	return LineReader(r, f)
 }
 
-func Lines(r io.Reader) (out []string, err error) {
+func Lines(r io.Reader, opts ...Option) (out []string, err error) {
 	_, err = LineReader(r, func(s string) error {
 		out = append(out, s)
 		return nil
-	})
+	}, opts...)
 	return
 }
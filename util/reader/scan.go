@@ -0,0 +1,74 @@
+package reader
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+// Default maximum line length for LineReader/Scan - longer lines fail with
+// bufio.ErrTooLong
+const DefaultMaxLineSize = 1024 * 1024
+
+// Scanner option for LineReader/Lines/Scan
+type Option func(*bufio.Scanner)
+
+// Set maximum line length
+func MaxLineSize(n int) Option {
+	return func(s *bufio.Scanner) { s.Buffer(nil, n) }
+}
+
+func newScanner(r io.Reader, opts []Option) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, DefaultMaxLineSize)
+	for _, opt := range opts {
+		opt(scanner)
+	}
+	return scanner
+}
+
+type Record[T any] struct {
+	Val  T
+	Line int
+	Err  error
+}
+
+// Stream records parsed from each line of r. The channel is closed at EOF,
+// after the first error (sent as a Record with Err set - parse errors are
+// returned as a *ParseError) or when ctx is cancelled. Callers which stop
+// reading early must cancel ctx.
+func Scan[T any](ctx context.Context, r io.Reader, parse func(string) (T, error), opts ...Option) <-chan Record[T] {
+	out := make(chan Record[T])
+	go func() {
+		defer close(out)
+		send := func(rec Record[T]) bool {
+			select {
+			case out <- rec:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		scanner := newScanner(r, opts)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if ctx.Err() != nil {
+				return
+			}
+			v, err := parse(scanner.Text())
+			if err != nil {
+				send(Record[T]{Line: line, Err: lineError(err, line, scanner.Text())})
+				return
+			}
+			if !send(Record[T]{Val: v, Line: line}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(Record[T]{Line: line + 1, Err: fmt.Errorf("Scanner Error: %w", err)})
+		}
+	}()
+	return out
+}
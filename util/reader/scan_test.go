@@ -0,0 +1,66 @@
+package reader
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestScan(t *testing.T) {
+	out := []int{}
+	for v := range Scan(context.Background(), strings.NewReader("1\n2\n3"), strconv.Atoi) {
+		if v.Err != nil {
+			t.Fatal(v.Err)
+		}
+		out = append(out, v.Val)
+	}
+	if !slices.Equal(out, []int{1, 2, 3}) {
+		t.Error(out)
+	}
+}
+
+func TestScanError(t *testing.T) {
+	records := []Record[int]{}
+	for v := range Scan(context.Background(), strings.NewReader("1\nx\n3"), strconv.Atoi) {
+		records = append(records, v)
+	}
+	var pe *ParseError
+	if len(records) != 2 || records[0].Val != 1 || !errors.As(records[1].Err, &pe) || pe.Line != 2 || !errors.Is(records[1].Err, strconv.ErrSyntax) {
+		t.Error(records)
+	}
+}
+
+func TestScanCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Scan(ctx, strings.NewReader(strings.Repeat("1\n", 1000)), strconv.Atoi)
+	<-ch
+	cancel()
+	n := 0
+	for range ch {
+		n++
+	}
+	if n > 1 {
+		t.Error("Expected channel to close after cancel", n)
+	}
+}
+
+func TestLongLines(t *testing.T) {
+	long := strings.Repeat("<>", 100*1024)
+	lines, err := Lines(strings.NewReader("a\n" + long + "\nb"))
+	if err != nil || len(lines) != 3 || lines[1] != long {
+		t.Error(len(lines), err)
+	}
+	if _, err := Lines(strings.NewReader(long), MaxLineSize(1024)); !errors.Is(err, bufio.ErrTooLong) {
+		t.Error(err)
+	}
+	for rec := range Scan(context.Background(), strings.NewReader(long), func(s string) (string, error) { return s, nil }, MaxLineSize(1024)) {
+		if !errors.Is(rec.Err, bufio.ErrTooLong) {
+			t.Error(rec.Err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+)
+
+// Section separator matching empty lines (or a lone \r from CRLF input).
+// Lines containing other whitespace are kept as they may be significant (eg.
+// a row of spaces in a map)
+func Blank(s string) bool {
+	return s == "" || s == "\r"
+}
+
+type section struct {
+	line  int // First line (1-based)
+	lines []string
+}
+
+func readSections(r io.Reader, sep func(string) bool) (out []section, err error) {
+	line := 0
+	current := section{}
+	_, err = LineReader(r, func(s string) error {
+		line++
+		if sep(s) {
+			if len(current.lines) > 0 {
+				out = append(out, current)
+			}
+			current = section{}
+		} else {
+			if len(current.lines) == 0 {
+				current.line = line
+			}
+			current.lines = append(current.lines, s)
+		}
+		return nil
+	})
+	if len(current.lines) > 0 {
+		out = append(out, current)
+	}
+	return
+}
+
+// Split r into sections on lines where sep is true (eg. reader.Blank).
+// Separator lines are dropped and runs of separators (or separators at the
+// start/end of the input) don't produce empty sections.
+func Sections(r io.Reader, sep func(string) bool) (out [][]string, err error) {
+	sections, err := readSections(r, sep)
+	for _, v := range sections {
+		out = append(out, v.lines)
+	}
+	return
+}
+
+// Decode sections into out using one parser per section - if there are more
+// sections than parsers the last parser is used for the remaining sections
+// (eg. a header followed by a list of records)
+func ParseSections[T any](r io.Reader, sep func(string) bool, parsers ...func(lines []string, out *T) error) (out T, err error) {
+	if len(parsers) == 0 {
+		return out, fmt.Errorf("No section parsers")
+	}
+	sections, err := readSections(r, sep)
+	if err != nil {
+		return out, err
+	}
+	if len(sections) < len(parsers) {
+		return out, fmt.Errorf("Expected %d sections: found %d", len(parsers), len(sections))
+	}
+	for i, v := range sections {
+		f := parsers[len(parsers)-1]
+		if i < len(parsers) {
+			f = parsers[i]
+		}
+		if err := f(v.lines, &out); err != nil {
+			return out, fmt.Errorf("Section %d (line %d): %w", i+1, v.line, err)
+		}
+	}
+	return out, nil
+}
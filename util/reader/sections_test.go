@@ -0,0 +1,86 @@
+package reader
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+const sectionData = `
+
+header
+
+
+1
+2
+
+
+3
+4
+5
+`
+
+func TestSections(t *testing.T) {
+	out, err := Sections(strings.NewReader(sectionData), Blank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"header"}, {"1", "2"}, {"3", "4", "5"}}
+	if len(out) != len(expected) {
+		t.Fatal(out)
+	}
+	for i := range out {
+		if !slices.Equal(out[i], expected[i]) {
+			t.Error(out[i], expected[i])
+		}
+	}
+	out, _ = Sections(strings.NewReader("a\r\n\r\n  \nb\n"), Blank)
+	if len(out) != 2 || !slices.Equal(out[1], []string{"  ", "b"}) {
+		t.Error(out)
+	}
+	out, _ = Sections(strings.NewReader("a\n--\nb"), func(s string) bool { return s == "--" })
+	if len(out) != 2 || out[1][0] != "b" {
+		t.Error(out)
+	}
+}
+
+type sectionResult struct {
+	title  string
+	groups [][]int
+}
+
+func parseGroup(lines []string, out *sectionResult) error {
+	g := []int{}
+	for _, v := range lines {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		g = append(g, i)
+	}
+	out.groups = append(out.groups, g)
+	return nil
+}
+
+func TestParseSections(t *testing.T) {
+	out, err := ParseSections(strings.NewReader(sectionData), Blank,
+		func(lines []string, out *sectionResult) error { out.title = lines[0]; return nil },
+		parseGroup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.title != "header" || len(out.groups) != 2 || !slices.Equal(out.groups[1], []int{3, 4, 5}) {
+		t.Error(out)
+	}
+	// Header parsed as group
+	_, err = ParseSections(strings.NewReader(sectionData), Blank, parseGroup)
+	if err == nil || !strings.HasPrefix(err.Error(), "Section 1 (line 3): ") {
+		t.Error(err)
+	}
+	_, err = ParseSections(strings.NewReader("1"), Blank, parseGroup, parseGroup)
+	if err == nil {
+		t.Error("Expected error")
+	}
+}
@@ -0,0 +1,86 @@
+package reader
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/paulc/aoc2022/util"
+)
+
+// Call f for each number in r (line is 1-based and match offsets are
+// relative to the line) - match is one of the util.Match* functions, eg.
+//
+//	reader.Matches(r, util.MatchInts, ',', f)
+func Matches[T any](r io.Reader, match func(string, rune) ([]util.Match[T], error), sep rune, f func(line int, m util.Match[T]) error) (int, error) {
+	line := 0
+	return LineReader(r, func(s string) error {
+		line++
+		m, err := match(s, sep)
+		if err != nil {
+			return err
+		}
+		for _, v := range m {
+			if err := f(line, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Number found in r (Start/End are byte offsets into the line)
+type LineMatch[T any] struct {
+	util.Match[T]
+	Line int
+}
+
+func matchAll[T any](r io.Reader, match func(string, rune) ([]util.Match[T], error), sep rune) (out []LineMatch[T], err error) {
+	_, err = Matches(r, match, sep, func(line int, m util.Match[T]) error {
+		out = append(out, LineMatch[T]{m, line})
+		return nil
+	})
+	return
+}
+
+func values[T any](m []LineMatch[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return util.Map(m, func(v LineMatch[T]) T { return v.Val }), nil
+}
+
+// All signed integers in r with positions (sep is an optional thousands
+// separator - 0 for none)
+func MatchInts(r io.Reader, sep rune) ([]LineMatch[int], error) {
+	return matchAll(r, util.MatchInts, sep)
+}
+
+func MatchInt64(r io.Reader, sep rune) ([]LineMatch[int64], error) {
+	return matchAll(r, util.MatchInt64, sep)
+}
+
+func MatchFloats(r io.Reader, sep rune) ([]LineMatch[float64], error) {
+	return matchAll(r, util.MatchFloats, sep)
+}
+
+func MatchBigInts(r io.Reader, sep rune) ([]LineMatch[*big.Int], error) {
+	return matchAll(r, util.MatchBigInts, sep)
+}
+
+// All signed integers in r (sep is an optional thousands separator - 0 for
+// none)
+func SlurpInts(r io.Reader, sep rune) ([]int, error) {
+	return values(MatchInts(r, sep))
+}
+
+func SlurpInt64(r io.Reader, sep rune) ([]int64, error) {
+	return values(MatchInt64(r, sep))
+}
+
+func SlurpFloats(r io.Reader, sep rune) ([]float64, error) {
+	return values(MatchFloats(r, sep))
+}
+
+func SlurpBigInts(r io.Reader, sep rune) ([]*big.Int, error) {
+	return values(MatchBigInts(r, sep))
+}
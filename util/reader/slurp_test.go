@@ -0,0 +1,48 @@
+package reader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/paulc/aoc2022/util"
+	"golang.org/x/exp/slices"
+)
+
+func TestSlurpInts(t *testing.T) {
+	out, err := SlurpInts(strings.NewReader("move 1 from -2\n\nto 3,4\n"), 0)
+	if err != nil || !slices.Equal(out, []int{1, -2, 3, 4}) {
+		t.Error(out, err)
+	}
+	if out, err := SlurpInts(strings.NewReader("1,000 -2_500\n"), '_'); err != nil || !slices.Equal(out, []int{1, 0, -2500}) {
+		t.Error(out, err)
+	}
+	if out, err := SlurpFloats(strings.NewReader("1,234.5\n-.5"), ','); err != nil || !slices.Equal(out, []float64{1234.5, -.5}) {
+		t.Error(out, err)
+	}
+	if _, err := SlurpInts(strings.NewReader("ok 1\nbad 99999999999999999999\n"), 0); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestMatchInts(t *testing.T) {
+	out, err := MatchInts(strings.NewReader("x=1\n\ny=-20"), 0)
+	if err != nil || len(out) != 2 || out[1].Line != 3 || out[1].Start != 2 || out[1].End != 5 || out[1].Val != -20 {
+		t.Error(out, err)
+	}
+	big, err := MatchBigInts(strings.NewReader("n=123,456,789,012,345,678,901"), ',')
+	if err != nil || len(big) != 1 || big[0].Val.String() != "123456789012345678901" || big[0].Line != 1 {
+		t.Error(big, err)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	type pos struct{ line, start, val int }
+	out := []pos{}
+	_, err := Matches(strings.NewReader("a 1,000\nb\nc -5 6"), util.MatchInts, ',', func(line int, m util.Match[int]) error {
+		out = append(out, pos{line, m.Start, m.Val})
+		return nil
+	})
+	if err != nil || !slices.Equal(out, []pos{{1, 2, 1000}, {3, 2, -5}, {3, 5, 6}}) {
+		t.Error(out, err)
+	}
+}
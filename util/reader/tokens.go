@@ -0,0 +1,230 @@
+package reader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// Token stream for writing small parsers. Errors are sticky - after the
+// first error all methods return zero values and Err returns the error (a
+// *ParseError with the column of the offending token) eg.
+//
+//	t.Keyword("move")
+//	n := t.Int()
+//	t.Keyword("from")
+//	from := t.Int()
+//	return move{n, from}, t.Err()
+type Tokens struct {
+	text   string
+	tokens []Token
+	pos    int
+	err    error
+	// Furthest error from a rule which was backtracked (reported in
+	// preference to a later error at an earlier column)
+	furthest *ParseError
+}
+
+func NewTokens(s string) (*Tokens, error) {
+	tokens, err := Lex(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{text: s, tokens: tokens}, nil
+}
+
+func (t *Tokens) Err() error {
+	return t.err
+}
+
+// Next token without consuming it (EOF at end of input)
+func (t *Tokens) Peek() Token {
+	if t.pos >= len(t.tokens) {
+		return Token{Kind: EOF, Pos: len(t.text)}
+	}
+	return t.tokens[t.pos]
+}
+
+func (t *Tokens) Next() Token {
+	tok := t.Peek()
+	if tok.Kind != EOF {
+		t.pos++
+	}
+	return tok
+}
+
+func (t *Tokens) AtEOF() bool {
+	return t.Peek().Kind == EOF
+}
+
+// Record error at the current token (keeping the first error)
+func (t *Tokens) Errorf(format string, args ...any) {
+	if t.err == nil {
+		t.err = &ParseError{Column: t.Peek().Pos + 1, Text: t.text, Err: fmt.Errorf(format, args...)}
+		if t.furthest != nil && t.furthest.Column >= t.err.(*ParseError).Column {
+			t.err = t.furthest
+		}
+	}
+}
+
+// Consume token of kind (with one of texts if given)
+func (t *Tokens) Expect(kind TokenKind, texts ...string) Token {
+	if t.err != nil {
+		return Token{}
+	}
+	tok := t.Peek()
+	if tok.Kind != kind || (len(texts) > 0 && !slices.Contains(texts, tok.Text)) {
+		expected := kind.String()
+		if len(texts) > 0 {
+			expected = strings.Join(quoteAll(texts), " or ")
+		}
+		t.Errorf("Expected %s, found %s", expected, tok)
+		return Token{}
+	}
+	return t.Next()
+}
+
+func quoteAll(s []string) (out []string) {
+	for _, v := range s {
+		out = append(out, strconv.Quote(v))
+	}
+	return
+}
+
+func (t *Tokens) Int() int {
+	tok := t.Expect(Number)
+	if t.err != nil {
+		return 0
+	}
+	v, err := strconv.Atoi(tok.Text)
+	if err != nil {
+		t.pos--
+		t.Errorf("%w", err)
+	}
+	return v
+}
+
+// Identifier (one of names if given)
+func (t *Tokens) Ident(names ...string) string {
+	return t.Expect(Ident, names...).Text
+}
+
+func (t *Tokens) Keyword(name string) {
+	t.Expect(Ident, name)
+}
+
+func (t *Tokens) Punct(p string) {
+	t.Expect(Punct, p)
+}
+
+// Quoted string (unquoted value)
+func (t *Tokens) Quoted() string {
+	return t.Expect(String).Text
+}
+
+// Expect end of input
+func (t *Tokens) End() {
+	t.Expect(EOF)
+}
+
+// Parser combinators - a Rule reads from the token stream and sets an error
+// on failure
+type Rule[T any] func(*Tokens) T
+
+// Parse s using rule (which must consume all of the input)
+func Parse[T any](s string, rule Rule[T]) (out T, err error) {
+	t, err := NewTokens(s)
+	if err != nil {
+		return out, err
+	}
+	out = rule(t)
+	t.End()
+	return out, t.Err()
+}
+
+// Try rule restoring the stream if it fails
+func (t *Tokens) try(rule func(*Tokens)) error {
+	pos := t.pos
+	rule(t)
+	err := t.err
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok && (t.furthest == nil || pe.Column > t.furthest.Column) {
+			t.furthest = pe
+		}
+		t.pos, t.err = pos, nil
+	}
+	return err
+}
+
+// Match rule zero or more times (stopping if the rule matches without
+// consuming any tokens)
+func Many[T any](rule Rule[T]) Rule[[]T] {
+	return func(t *Tokens) (out []T) {
+		for t.err == nil && !t.AtEOF() {
+			var v T
+			pos := t.pos
+			if t.try(func(t *Tokens) { v = rule(t) }) != nil || t.pos == pos {
+				break
+			}
+			out = append(out, v)
+		}
+		return
+	}
+}
+
+// First matching rule (the error from the rule which got furthest is
+// returned if none match)
+func Alt[T any](rules ...Rule[T]) Rule[T] {
+	return func(t *Tokens) (out T) {
+		if t.err != nil {
+			return
+		}
+		for _, rule := range rules {
+			if t.try(func(t *Tokens) { out = rule(t) }) == nil {
+				return
+			}
+		}
+		var zero T
+		out = zero
+		t.Errorf("No matching rule")
+		return
+	}
+}
+
+// Optional rule (zero value if it doesn't match)
+func Optional[T any](rule Rule[T]) Rule[T] {
+	return func(t *Tokens) (out T) {
+		if t.err == nil {
+			if t.try(func(t *Tokens) { out = rule(t) }) != nil {
+				var zero T
+				out = zero
+			}
+		}
+		return
+	}
+}
+
+func Map[A, B any](rule Rule[A], f func(A) B) Rule[B] {
+	return func(t *Tokens) (out B) {
+		v := rule(t)
+		if t.err == nil {
+			out = f(v)
+		}
+		return
+	}
+}
+
+// Primitive rules
+func IntRule(t *Tokens) int {
+	return t.Int()
+}
+
+func IdentRule(names ...string) Rule[string] {
+	return func(t *Tokens) string { return t.Ident(names...) }
+}
+
+func PunctRule(p string) Rule[string] {
+	return func(t *Tokens) string { return t.Expect(Punct, p).Text }
+}
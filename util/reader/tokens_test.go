@@ -0,0 +1,116 @@
+package reader
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+type instr struct {
+	op  string
+	arg int
+}
+
+func parseInstr(t *Tokens) (out instr) {
+	out.op = t.Ident("noop", "addx")
+	if out.op == "addx" {
+		out.arg = t.Int()
+	}
+	return
+}
+
+func TestTokens(t *testing.T) {
+	if v, err := Parse("addx -15", parseInstr); err != nil || v != (instr{"addx", -15}) {
+		t.Error(v, err)
+	}
+	if v, err := Parse("noop", parseInstr); err != nil || v != (instr{"noop", 0}) {
+		t.Error(v, err)
+	}
+	var pe *ParseError
+	for _, v := range []struct {
+		in     string
+		column int
+	}{
+		{"addx x", 6},
+		{"subx 1", 1},
+		{"addx", 5},
+		{"noop 1", 6},
+		{"addx 99999999999999999999", 6},
+	} {
+		if _, err := Parse(v.in, parseInstr); !errors.As(err, &pe) || pe.Column != v.column || pe.Text != v.in {
+			t.Error(v.in, err)
+		}
+	}
+	tok, _ := NewTokens(`x = "s"`)
+	x := tok.Ident()
+	tok.Punct("=")
+	str := tok.Quoted()
+	if tok.End(); x != "x" || str != "s" || tok.Err() != nil {
+		t.Error(x, str, tok.Err())
+	}
+}
+
+type step struct {
+	n    int
+	turn string
+}
+
+func TestCombinators(t *testing.T) {
+	path := Many(Alt(
+		Map(IntRule, func(n int) step { return step{n: n} }),
+		Map(IdentRule("L", "R"), func(s string) step { return step{turn: s} }),
+	))
+	out, err := Parse("10R5 L12", path)
+	if err != nil || !slices.Equal(out, []step{{10, ""}, {0, "R"}, {5, ""}, {0, "L"}, {12, ""}}) {
+		t.Error(out, err)
+	}
+	var pe *ParseError
+	if _, err := Parse("10R5X", path); !errors.As(err, &pe) || pe.Column != 5 {
+		t.Error(err)
+	}
+	list := func(t *Tokens) (out []int) {
+		t.Punct("[")
+		out = append(out, t.Int())
+		out = append(out, Many(Map(func(t *Tokens) int { t.Punct(","); return t.Int() }, func(n int) int { return n }))(t)...)
+		t.Punct("]")
+		return
+	}
+	if v, err := Parse("[1, 2,3]", list); err != nil || !slices.Equal(v, []int{1, 2, 3}) {
+		t.Error(v, err)
+	}
+	sign := Optional(PunctRule("+"))
+	if v, err := Parse("+", sign); err != nil || v != "+" {
+		t.Error(v, err)
+	}
+	if v, err := Parse("", sign); err != nil || v != "" {
+		t.Error(v, err)
+	}
+	if _, err := Parse("1", Alt[int]()); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestManyEmpty(t *testing.T) {
+	var pe *ParseError
+	if _, err := Parse("a", Many(Optional(IntRule))); !errors.As(err, &pe) || pe.Column != 1 {
+		t.Error(err)
+	}
+	if v, err := Parse("1 2", Many(Optional(IntRule))); err != nil || !slices.Equal(v, []int{1, 2}) {
+		t.Error(v, err)
+	}
+}
+
+func TestManyError(t *testing.T) {
+	pair := func(t *Tokens) [2]int {
+		a := t.Int()
+		t.Punct(",")
+		return [2]int{a, t.Int()}
+	}
+	var pe *ParseError
+	_, err := Parse("1,2 3,4 5,x", Many(pair))
+	if !errors.As(err, &pe) || pe.Column != 11 || !strings.Contains(pe.Err.Error(), "Expected number") {
+		t.Error(err)
+	}
+}
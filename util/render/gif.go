@@ -0,0 +1,71 @@
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+// Record frames for an animated GIF
+type Recorder struct {
+	Delay  int // Delay between frames (100ths of a second)
+	frames []image.Image
+}
+
+func NewRecorder(delay int) *Recorder {
+	return &Recorder{Delay: delay}
+}
+
+func (r *Recorder) AddFrame(img image.Image) {
+	r.frames = append(r.frames, img)
+}
+
+func (r *Recorder) Len() int {
+	return len(r.frames)
+}
+
+// Write animation - uses the exact colours from the frames if there are
+// <= 256 (otherwise frames are mapped to the Plan9 palette)
+func (r *Recorder) Write(w io.Writer) error {
+	if len(r.frames) == 0 {
+		return errors.New("No frames")
+	}
+	p := r.palette()
+	out := &gif.GIF{}
+	bounds := image.Rectangle{}
+	for _, f := range r.frames {
+		bounds = bounds.Union(f.Bounds())
+	}
+	for _, f := range r.frames {
+		img := image.NewPaletted(bounds, p)
+		draw.Draw(img, f.Bounds(), f, f.Bounds().Min, draw.Src)
+		out.Image = append(out.Image, img)
+		out.Delay = append(out.Delay, r.Delay)
+	}
+	return gif.EncodeAll(w, out)
+}
+
+func (r *Recorder) palette() color.Palette {
+	seen := map[color.RGBA]bool{}
+	p := color.Palette{}
+	for _, f := range r.frames {
+		b := f.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := color.RGBAModel.Convert(f.At(x, y)).(color.RGBA)
+				if !seen[c] {
+					if len(p) == 256 {
+						return palette.Plan9
+					}
+					seen[c] = true
+					p = append(p, c)
+				}
+			}
+		}
+	}
+	return p
+}
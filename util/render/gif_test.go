@@ -0,0 +1,53 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/array"
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(10)
+	if err := r.Write(&bytes.Buffer{}); err == nil {
+		t.Error("Expected error")
+	}
+	a := array.Array[bool]{{false, false, false}, {false, false, false}}
+	for i := 0; i < 3; i++ {
+		a[i%2][i] = true
+		r.AddFrame(ArrayImage(a, bw, 2))
+	}
+	var b bytes.Buffer
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 3 || g.Delay[0] != 10 || len(g.Image[0].Palette) != 2 {
+		t.Error(len(g.Image), g.Delay, g.Image[0].Palette)
+	}
+	if r, _, _, _ := g.Image[2].At(4, 0).RGBA(); r != 0xffff {
+		t.Error(g.Image[2].At(4, 0))
+	}
+}
+
+func TestRecorderLargePalette(t *testing.T) {
+	r := NewRecorder(1)
+	img := image.NewRGBA(image.Rect(0, 0, 300, 1))
+	for x := 0; x < 300; x++ {
+		img.Set(x, 0, color.RGBA{uint8(x), uint8(x / 256), 0, 255})
+	}
+	r.AddFrame(img)
+	var b bytes.Buffer
+	if err := r.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gif.DecodeAll(&b); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/grid"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+// Render grid using palette function f - each cell is scale x scale pixels
+func GridImage[T any](g *grid.Grid[T], f func(T) color.Color, scale int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, g.Width*scale, g.Height*scale))
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			fill(img, x, y, scale, f(g.Get(point.Point{g.X0 + x, g.Y0 + y})))
+		}
+	}
+	return img
+}
+
+// Render array using palette function f - each cell is scale x scale pixels
+func ArrayImage[T any](a array.Array[T], f func(T) color.Color, scale int) *image.RGBA {
+	w := 0
+	for _, row := range a {
+		if len(row) > w {
+			w = len(row)
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w*scale, len(a)*scale))
+	for y, row := range a {
+		for x, v := range row {
+			fill(img, x, y, scale, f(v))
+		}
+	}
+	return img
+}
+
+func fill(img *image.RGBA, x, y, scale int, c color.Color) {
+	for dy := 0; dy < scale; dy++ {
+		for dx := 0; dx < scale; dx++ {
+			img.Set(x*scale+dx, y*scale+dy, c)
+		}
+	}
+}
+
+func WritePNG(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
+
+// Write binary (P6) PPM
+func WritePPM(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	out := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(out, "P6\n%d %d\n255\n", b.Dx(), b.Dy()); err != nil {
+		return err
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			if _, err := out.Write([]byte{c.R, c.G, c.B}); err != nil {
+				return err
+			}
+		}
+	}
+	return out.Flush()
+}
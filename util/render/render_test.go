@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/paulc/aoc2022/util/array"
+	"github.com/paulc/aoc2022/util/grid"
+	"github.com/paulc/aoc2022/util/point"
+)
+
+func bw(b bool) color.Color {
+	if b {
+		return color.White
+	}
+	return color.Black
+}
+
+func TestGridImage(t *testing.T) {
+	g, err := grid.NewGrid[bool](-1, -1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Set(point.Point{0, 0}, true)
+	img := GridImage(g, bw, 2)
+	if img.Bounds().Dx() != 6 || img.Bounds().Dy() != 4 {
+		t.Fatal(img.Bounds())
+	}
+	for _, v := range []struct {
+		x, y int
+		c    color.Color
+	}{{0, 0, color.Black}, {2, 2, color.White}, {3, 3, color.White}, {4, 2, color.Black}} {
+		if r, _, _, _ := img.At(v.x, v.y).RGBA(); r>>8 != map[color.Color]uint32{color.Black: 0, color.White: 255}[v.c] {
+			t.Error(v, img.At(v.x, v.y))
+		}
+	}
+}
+
+func TestArrayImagePNG(t *testing.T) {
+	a := array.Array[bool]{{true, false}, {false}}
+	img := ArrayImage(a, bw, 1)
+	var b bytes.Buffer
+	if err := WritePNG(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	img2, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img2.Bounds().Dx() != 2 || img2.Bounds().Dy() != 2 {
+		t.Error(img2.Bounds())
+	}
+	if r, _, _, _ := img2.At(0, 0).RGBA(); r != 0xffff {
+		t.Error(img2.At(0, 0))
+	}
+}
+
+func TestWritePPM(t *testing.T) {
+	a := array.Array[bool]{{true, false}}
+	var b bytes.Buffer
+	if err := WritePPM(&b, ArrayImage(a, bw, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "P6\n2 1\n255\n\xff\xff\xff\x00\x00\x00" {
+		t.Errorf("%q", b.String())
+	}
+}
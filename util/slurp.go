@@ -0,0 +1,137 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Number found by Match*/Slurp* - Start/End are byte offsets into the input
+type Match[T any] struct {
+	Val        T
+	Start, End int
+}
+
+const (
+	intPattern   = `[+-]?(%s)`
+	floatPattern = `[+-]?((%s)(\.\d*)?|\.\d+)([eE][+-]?\d+)?`
+)
+
+// Digits with optional thousands separator (0 for none). Any digits following
+// the last group are included so that a malformed number (eg. 1,2345) can be
+// detected and matched again without separators (see grouped)
+func digits(sep rune) string {
+	if sep == 0 {
+		return `\d+`
+	}
+	s := regexp.QuoteMeta(string(sep))
+	return fmt.Sprintf(`\d{1,3}(%s\d{3})+\d*|\d+`, s)
+}
+
+// Check that the digits following the last separator in v form a group of 3
+func grouped(v string, sep rune) bool {
+	i := strings.LastIndex(v, string(sep))
+	if i == -1 {
+		return true
+	}
+	n := 0
+	for _, c := range v[i+len(string(sep)):] {
+		if c < '0' || c > '9' {
+			break
+		}
+		n++
+	}
+	return n == 3
+}
+
+// Compiled patterns (keyed by pattern - there is one per type/separator)
+var patterns sync.Map
+
+func compile(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, _ := patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	return re.(*regexp.Regexp)
+}
+
+// A +/- is treated as a sign unless it directly follows a digit (so ranges
+// like 2-4 give 2,4 rather than 2,-4). Numbers with badly grouped digits are
+// split into plain digit runs (so 1,2345 gives 1,2345 rather than 1234,5)
+func match[T any](s string, format string, sep rune, parse func(string) (T, error)) (out []Match[T], err error) {
+	for _, m := range compile(fmt.Sprintf(format, digits(sep))).FindAllStringIndex(s, -1) {
+		start, end := m[0], m[1]
+		if (s[start] == '-' || s[start] == '+') && start > 0 && s[start-1] >= '0' && s[start-1] <= '9' {
+			start++
+		}
+		v := s[start:end]
+		if sep != 0 {
+			if !grouped(v, sep) {
+				plain, err := match(v, format, 0, parse)
+				if err != nil {
+					return nil, err
+				}
+				for _, p := range plain {
+					out = append(out, Match[T]{p.Val, start + p.Start, start + p.End})
+				}
+				continue
+			}
+			v = strings.ReplaceAll(v, string(sep), "")
+		}
+		val, err := parse(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Match[T]{val, start, end})
+	}
+	return
+}
+
+func values[T any](m []Match[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return Map(m, func(v Match[T]) T { return v.Val }), nil
+}
+
+func MatchInts(s string, sep rune) ([]Match[int], error) {
+	return match(s, intPattern, sep, strconv.Atoi)
+}
+
+func MatchInt64(s string, sep rune) ([]Match[int64], error) {
+	return match(s, intPattern, sep, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+}
+
+func MatchFloats(s string, sep rune) ([]Match[float64], error) {
+	return match(s, floatPattern, sep, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+}
+
+func MatchBigInts(s string, sep rune) ([]Match[*big.Int], error) {
+	return match(s, intPattern, sep, func(s string) (*big.Int, error) {
+		v, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("Invalid integer: %s", s)
+		}
+		return v, nil
+	})
+}
+
+// Extract signed integers from s (see MatchInts for positions/separators)
+func SlurpInts(s string) ([]int, error) {
+	return values(MatchInts(s, 0))
+}
+
+func SlurpInt64(s string) ([]int64, error) {
+	return values(MatchInt64(s, 0))
+}
+
+func SlurpFloats(s string) ([]float64, error) {
+	return values(MatchFloats(s, 0))
+}
+
+func SlurpBigInts(s string) ([]*big.Int, error) {
+	return values(MatchBigInts(s, 0))
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"math/big"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSlurpInts(t *testing.T) {
+	for _, v := range []struct {
+		in       string
+		expected []int
+	}{
+		{"Sensor at x=-2, y=15: closest beacon is at x=+10, y=-16", []int{-2, 15, 10, -16}},
+		{"2-4,6-8", []int{2, 4, 6, 8}},
+		{`aa1234:"|777--888kkk`, []int{1234, 777, -888}},
+		{"no numbers", nil},
+	} {
+		if out := Must(SlurpInts(v.in)); !slices.Equal(out, v.expected) {
+			t.Error(v.in, out)
+		}
+	}
+	if _, err := SlurpInts("99999999999999999999"); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestMatchInts(t *testing.T) {
+	s := "Total: -1,234,567 from 12 items (3,45)"
+	m := Must(MatchInts(s, ','))
+	if !slices.Equal(Map(m, func(m Match[int]) int { return m.Val }), []int{-1234567, 12, 3, 45}) {
+		t.Error(m)
+	}
+	for _, v := range m {
+		if v.Val == -1234567 && s[v.Start:v.End] != "-1,234,567" {
+			t.Error(s[v.Start:v.End])
+		}
+	}
+	if m := Must(MatchInt64("x=-9000000000 y=1_000", '_')); len(m) != 2 || m[0].Val != -9000000000 || m[1].Val != 1000 || m[1].Start != 16 {
+		t.Error(m)
+	}
+	s = "a=1,2345 b=-12,345,6789 c=1,234.5"
+	if m := Must(MatchInts(s, ',')); !slices.Equal(Map(m, func(m Match[int]) int { return m.Val }), []int{1, 2345, -12, 345, 6789, 1234, 5}) || s[m[1].Start:m[1].End] != "2345" {
+		t.Error(m)
+	}
+	if m := Must(MatchFloats(s, ',')); !slices.Equal(Map(m, func(m Match[float64]) float64 { return m.Val }), []float64{1, 2345, -12, 345, 6789, 1234.5}) {
+		t.Error(m)
+	}
+}
+
+func TestSlurpFloats(t *testing.T) {
+	out := Must(SlurpFloats("a=1.5 b=-.25 c=3e2 d=7 e=2-4"))
+	if !slices.Equal(out, []float64{1.5, -0.25, 300, 7, 2, 4}) {
+		t.Error(out)
+	}
+}
+
+func TestSlurpBigInts(t *testing.T) {
+	out := Must(SlurpBigInts("x=-123456789012345678901234567890, y=42"))
+	expected, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	if len(out) != 2 || out[0].Cmp(expected) != 0 || out[1].Int64() != 42 {
+		t.Error(out)
+	}
+}
+
+func TestCompileCached(t *testing.T) {
+	if compile(`\d+`) != compile(`\d+`) {
+		t.Error("Expected cached pattern")
+	}
+}
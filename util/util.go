@@ -45,6 +45,7 @@ func Split[T any](in []T, f func(T) bool) (head, tail []T) {
 	return
 }
 
+// Unsigned integers only (- is treated as a separator) - see SlurpInts
 func SlurpInt(s string) (out []int, err error) {
 	for _, v := range regexp.MustCompile(`\D+`).Split(s, -1) {
 		if len(v) > 0 {
@@ -98,6 +99,7 @@ func Min[T ~int | ~float32 | ~float64](in ...T) (out T) {
 }
 
 func Max[T ~int | ~float32 | ~float64](in ...T) (out T) {
+	out = in[0]
 	for _, v := range in {
 		if v > out {
 			out = v
@@ -78,13 +78,13 @@ func TestReduce(t *testing.T) {
 }
 
 func TestMin(t *testing.T) {
-	if Min(2, 1, 5) != 1 || Min(1.5, 1.4) != 1.4 {
+	if Min(2, 1, 5) != 1 || Min(1.5, 1.4) != 1.4 || Min(-3, -2) != -3 {
 		t.Error("Min")
 	}
 }
 
 func TestMax(t *testing.T) {
-	if Max(2, 1, 5) != 5 || Max(1.5, 1.4) != 1.5 {
+	if Max(2, 1, 5) != 5 || Max(1.5, 1.4) != 1.5 || Max(-3, -2) != -2 {
 		t.Error("Max")
 	}
 }
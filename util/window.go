@@ -0,0 +1,71 @@
+package util
+
+// Sliding window queries - each returns one result per window in[i:i+n]
+// (len(in)-n+1 results, none if n <= 0 or n > len(in))
+
+// Number of distinct values in each window
+func WindowDistinct[T comparable](in []T, n int) (out []int) {
+	if n <= 0 {
+		return nil
+	}
+	count := make(map[T]int)
+	for i, v := range in {
+		count[v]++
+		if i >= n {
+			if count[in[i-n]]--; count[in[i-n]] == 0 {
+				delete(count, in[i-n])
+			}
+		}
+		if i >= n-1 {
+			out = append(out, len(count))
+		}
+	}
+	return
+}
+
+func WindowSum[T ~int | ~float32 | ~float64](in []T, n int) (out []T) {
+	if n <= 0 {
+		return nil
+	}
+	var sum T
+	for i, v := range in {
+		sum += v
+		if i >= n {
+			sum -= in[i-n]
+		}
+		if i >= n-1 {
+			out = append(out, sum)
+		}
+	}
+	return
+}
+
+func WindowMin[T ~int | ~float32 | ~float64](in []T, n int) []T {
+	return windowMonotone(in, n, func(a, b T) bool { return a <= b })
+}
+
+func WindowMax[T ~int | ~float32 | ~float64](in []T, n int) []T {
+	return windowMonotone(in, n, func(a, b T) bool { return a >= b })
+}
+
+// Monotone deque of indexes - the front of the deque is the best value in
+// the window (keep(a,b) is true if a should be kept in front of b)
+func windowMonotone[T any](in []T, n int, keep func(a, b T) bool) (out []T) {
+	if n <= 0 {
+		return nil
+	}
+	deque := []int{}
+	for i, v := range in {
+		for len(deque) > 0 && !keep(in[deque[len(deque)-1]], v) {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+		if deque[0] <= i-n {
+			deque = deque[1:]
+		}
+		if i >= n-1 {
+			out = append(out, in[deque[0]])
+		}
+	}
+	return
+}
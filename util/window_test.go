@@ -0,0 +1,62 @@
+package util
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestWindowDistinct(t *testing.T) {
+	if w := WindowDistinct([]byte("mjqjpqmgbljsphdztnvjfqwrcgsmlb"), 4); slices.Index(w, 4)+4 != 7 {
+		t.Error(w)
+	}
+	if w := WindowDistinct([]int{1, 1, 2, 3, 3}, 2); !slices.Equal(w, []int{1, 2, 2, 1}) {
+		t.Error(w)
+	}
+	if w := WindowDistinct([]int{1, 2}, 3); len(w) != 0 {
+		t.Error(w)
+	}
+}
+
+func TestWindowSum(t *testing.T) {
+	if w := WindowSum([]int{1, 2, 3, 4, 5}, 3); !slices.Equal(w, []int{6, 9, 12}) {
+		t.Error(w)
+	}
+	if w := WindowSum([]float64{0.5, 1.5}, 1); !slices.Equal(w, []float64{0.5, 1.5}) {
+		t.Error(w)
+	}
+}
+
+func TestWindowMinMax(t *testing.T) {
+	in := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	if w := WindowMax(in, 3); !slices.Equal(w, []int{3, 3, 5, 5, 6, 7}) {
+		t.Error(w)
+	}
+	if w := WindowMin(in, 3); !slices.Equal(w, []int{-1, -3, -3, -3, 3, 3}) {
+		t.Error(w)
+	}
+	if w := WindowMin([]int{2, 2, 2}, 2); !slices.Equal(w, []int{2, 2}) {
+		t.Error(w)
+	}
+}
+
+func TestWindowSize(t *testing.T) {
+	in := []int{3, 1, 2}
+	for _, n := range []int{-1, 0, 4} {
+		if w := WindowDistinct(in, n); len(w) != 0 {
+			t.Error("WindowDistinct", n, w)
+		}
+		if w := WindowSum(in, n); len(w) != 0 {
+			t.Error("WindowSum", n, w)
+		}
+		if w := WindowMin(in, n); len(w) != 0 {
+			t.Error("WindowMin", n, w)
+		}
+		if w := WindowMax(in, n); len(w) != 0 {
+			t.Error("WindowMax", n, w)
+		}
+	}
+	if w := WindowMax(in, 3); !slices.Equal(w, []int{3}) {
+		t.Error(w)
+	}
+}